@@ -3,10 +3,12 @@ package main
 import (
 	"log"
 	"os"
+	"time"
 
 	"github.com/AlexTLDR/evite/internal/config"
 	"github.com/AlexTLDR/evite/internal/database"
 	"github.com/AlexTLDR/evite/internal/server"
+	"github.com/AlexTLDR/evite/internal/server/handlers"
 	"github.com/joho/godotenv"
 )
 
@@ -43,6 +45,25 @@ func main() {
 		log.Fatalf("Failed to run migrations: %v", err)
 	}
 
+	// Seed owners from ADMIN_EMAILS on first start so there is someone who can manage admins
+	seeded, err := db.SeedOwners(cfg.AdminEmails)
+	if err != nil {
+		log.Fatalf("Failed to seed admins: %v", err)
+	}
+	if seeded {
+		log.Printf("Seeded owners from ADMIN_EMAILS")
+	}
+
+	// Give invitations created before short codes existed a code
+	if err := db.EnsureInvitationCodes(); err != nil {
+		log.Fatalf("Failed to assign invitation codes: %v", err)
+	}
+
+	// Permanently delete invitations that have been archived for too long
+	if cfg.ArchivePurgeAfter > 0 {
+		go purgeArchivedInvitations(db, cfg.ArchivePurgeAfter)
+	}
+
 	// Create and start the server
 	srv := server.New(cfg, db)
 
@@ -56,3 +77,19 @@ func main() {
 		log.Fatalf("Server failed: %v", err)
 	}
 }
+
+// purgeArchivedInvitations deletes expired archived invitations at startup and then every hour
+func purgeArchivedInvitations(db *database.DB, after time.Duration) {
+	ticker := time.NewTicker(time.Hour)
+	defer ticker.Stop()
+
+	for {
+		purged, err := handlers.PurgeExpiredInvitations(db, time.Now().Add(-after))
+		if err != nil {
+			log.Printf("Warning: Failed to purge archived invitations: %v", err)
+		} else if purged > 0 {
+			log.Printf("Purged %d archived invitations", purged)
+		}
+		<-ticker.C
+	}
+}
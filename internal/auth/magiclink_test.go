@@ -0,0 +1,48 @@
+package auth
+
+import "testing"
+
+func TestLoginTokenSignature(t *testing.T) {
+	token, err := NewLoginToken()
+	if err != nil {
+		t.Fatalf("Failed to create token: %v", err)
+	}
+	signed := SignLoginToken("secret", token)
+
+	tests := []struct {
+		name     string
+		secret   string
+		signed   string
+		expected bool
+	}{
+		{"valid", "secret", signed, true},
+		{"wrong secret", "other", signed, false},
+		{"tampered token", "secret", "x" + signed, false},
+		{"missing signature", "secret", token, false},
+		{"empty", "secret", "", false},
+		{"only signature", "secret", "." + loginTokenSignature("secret", ""), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, ok := VerifyLoginToken(tt.secret, tt.signed)
+			if ok != tt.expected {
+				t.Errorf("Expected %v but got %v", tt.expected, ok)
+			}
+			if ok && result != token {
+				t.Errorf("Expected token %q but got %q", token, result)
+			}
+		})
+	}
+}
+
+func TestNewLoginTokenUnique(t *testing.T) {
+	first, _ := NewLoginToken()
+	second, _ := NewLoginToken()
+	if first == second {
+		t.Errorf("Expected different tokens but got %q twice", first)
+	}
+	if HashLoginToken(first) == HashLoginToken(second) {
+		t.Errorf("Expected different hashes for different tokens")
+	}
+}
@@ -0,0 +1,206 @@
+package auth
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-jose/go-jose/v4"
+)
+
+// mockIssuer is a minimal OpenID Connect issuer that returns a signed ID token
+// with the configured claims from its token endpoint
+type mockIssuer struct {
+	server *httptest.Server
+	key    *rsa.PrivateKey
+	claims map[string]interface{}
+}
+
+func newMockIssuer(t *testing.T) *mockIssuer {
+	t.Helper()
+
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("Failed to generate key: %v", err)
+	}
+
+	m := &mockIssuer{key: key}
+	mux := http.NewServeMux()
+	m.server = httptest.NewServer(mux)
+	t.Cleanup(m.server.Close)
+
+	mux.HandleFunc("/.well-known/openid-configuration", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"issuer":                                m.server.URL,
+			"authorization_endpoint":                m.server.URL + "/authorize",
+			"token_endpoint":                        m.server.URL + "/token",
+			"jwks_uri":                              m.server.URL + "/keys",
+			"id_token_signing_alg_values_supported": []string{"RS256"},
+		})
+	})
+	mux.HandleFunc("/keys", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(jose.JSONWebKeySet{Keys: []jose.JSONWebKey{
+			{Key: &key.PublicKey, KeyID: "test", Algorithm: "RS256", Use: "sig"},
+		}})
+	})
+	mux.HandleFunc("/token", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"access_token": "access",
+			"token_type":   "Bearer",
+			"id_token":     m.sign(t),
+		})
+	})
+
+	return m
+}
+
+// sign returns the configured claims as a signed JWT
+func (m *mockIssuer) sign(t *testing.T) string {
+	signer, err := jose.NewSigner(
+		jose.SigningKey{Algorithm: jose.RS256, Key: m.key},
+		(&jose.SignerOptions{}).WithType("JWT").WithHeader("kid", "test"),
+	)
+	if err != nil {
+		t.Fatalf("Failed to create signer: %v", err)
+	}
+	payload, _ := json.Marshal(m.claims)
+	signed, err := signer.Sign(payload)
+	if err != nil {
+		t.Fatalf("Failed to sign token: %v", err)
+	}
+	token, _ := signed.CompactSerialize()
+	return token
+}
+
+func TestOIDCProviderExchange(t *testing.T) {
+	issuer := newMockIssuer(t)
+	now := time.Now()
+
+	baseClaims := func() map[string]interface{} {
+		return map[string]interface{}{
+			"iss":   issuer.server.URL,
+			"aud":   "evite",
+			"sub":   "123",
+			"iat":   now.Unix(),
+			"exp":   now.Add(time.Hour).Unix(),
+			"email": "cohost@example.com",
+			"name":  "Co Host",
+		}
+	}
+
+	tests := []struct {
+		name       string
+		emailClaim string
+		modify     func(claims map[string]interface{})
+		expected   *Identity
+		wantErr    string
+	}{
+		{
+			name:     "valid token",
+			modify:   func(claims map[string]interface{}) {},
+			expected: &Identity{Email: "cohost@example.com", Name: "Co Host"},
+		},
+		{
+			name:       "custom email claim",
+			emailClaim: "preferred_username",
+			modify: func(claims map[string]interface{}) {
+				delete(claims, "email")
+				claims["preferred_username"] = "bunica@example.com"
+			},
+			expected: &Identity{Email: "bunica@example.com", Name: "Co Host"},
+		},
+		{
+			name:    "wrong audience",
+			modify:  func(claims map[string]interface{}) { claims["aud"] = "someone-else" },
+			wantErr: "failed to verify id_token",
+		},
+		{
+			name:    "wrong issuer",
+			modify:  func(claims map[string]interface{}) { claims["iss"] = "https://evil.example.com" },
+			wantErr: "failed to verify id_token",
+		},
+		{
+			name:    "expired token",
+			modify:  func(claims map[string]interface{}) { claims["exp"] = now.Add(-time.Hour).Unix() },
+			wantErr: "failed to verify id_token",
+		},
+		{
+			name:    "unverified email",
+			modify:  func(claims map[string]interface{}) { claims["email_verified"] = false },
+			wantErr: "not verified",
+		},
+		{
+			name:       "unverified email with custom email claim",
+			emailClaim: "preferred_username",
+			modify: func(claims map[string]interface{}) {
+				claims["email_verified"] = false
+				claims["preferred_username"] = "bunica@example.com"
+			},
+			wantErr: "not verified",
+		},
+		{
+			name:    "missing email",
+			modify:  func(claims map[string]interface{}) { delete(claims, "email") },
+			wantErr: "no \"email\" claim",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			claims := baseClaims()
+			tt.modify(claims)
+			issuer.claims = claims
+
+			provider, err := NewOIDCProvider(context.Background(), OIDCConfig{
+				IssuerURL:  issuer.server.URL,
+				ClientID:   "evite",
+				EmailClaim: tt.emailClaim,
+			})
+			if err != nil {
+				t.Fatalf("Failed to create provider: %v", err)
+			}
+
+			identity, err := provider.Exchange(context.Background(), "code")
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Errorf("Expected error containing %q but got %v", tt.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if *identity != *tt.expected {
+				t.Errorf("Expected %+v but got %+v", tt.expected, identity)
+			}
+		})
+	}
+}
+
+func TestOIDCProviderScopes(t *testing.T) {
+	issuer := newMockIssuer(t)
+
+	provider, err := NewOIDCProvider(context.Background(), OIDCConfig{
+		IssuerURL: issuer.server.URL,
+		ClientID:  "evite",
+		Scopes:    []string{"email", "openid", "profile"},
+	})
+	if err != nil {
+		t.Fatalf("Failed to create provider: %v", err)
+	}
+
+	url := provider.AuthCodeURL("xyz")
+	if !strings.HasPrefix(url, issuer.server.URL+"/authorize?") {
+		t.Errorf("Expected the discovered authorization endpoint but got %s", url)
+	}
+	if !strings.Contains(url, "scope=openid+email+profile") {
+		t.Errorf("Expected openid to be requested once and first but got %s", url)
+	}
+}
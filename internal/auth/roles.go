@@ -0,0 +1,82 @@
+package auth
+
+import "context"
+
+// Role is the access level of an admin
+type Role string
+
+const (
+	RoleOwner   Role = "owner"
+	RoleEditor  Role = "editor"
+	RoleViewer  Role = "viewer"
+	RoleCheckIn Role = "checkin"
+)
+
+// Roles lists all roles, from most to least privileged
+var Roles = []Role{RoleOwner, RoleEditor, RoleViewer, RoleCheckIn}
+
+// Permission is an action an admin route requires
+type Permission string
+
+const (
+	// PermView allows viewing the dashboard and the invitations list
+	PermView Permission = "view"
+	// PermReports allows exports and reports (CSV, catering)
+	PermReports Permission = "reports"
+	// PermEdit allows creating and updating invitations
+	PermEdit Permission = "edit"
+	// PermDelete allows deleting invitations
+	PermDelete Permission = "delete"
+	// PermManageAdmins allows managing admins and reading the audit log
+	PermManageAdmins Permission = "manage_admins"
+	// PermCheckIn allows checking guests in at the event
+	PermCheckIn Permission = "check_in"
+	// PermAccount allows changing one's own language and signing out one's own sessions
+	PermAccount Permission = "account"
+)
+
+// rolePermissions maps each role to the permissions it grants
+var rolePermissions = map[Role][]Permission{
+	RoleOwner:   {PermView, PermReports, PermEdit, PermDelete, PermManageAdmins, PermCheckIn, PermAccount},
+	RoleEditor:  {PermView, PermReports, PermEdit, PermCheckIn, PermAccount},
+	RoleViewer:  {PermView, PermReports, PermAccount},
+	RoleCheckIn: {PermCheckIn, PermAccount},
+}
+
+// ParseRole converts a role name to a Role
+func ParseRole(name string) (Role, bool) {
+	for _, role := range Roles {
+		if string(role) == name {
+			return role, true
+		}
+	}
+	return "", false
+}
+
+// Can reports whether the role grants the permission
+func (r Role) Can(permission Permission) bool {
+	for _, p := range rolePermissions[r] {
+		if p == permission {
+			return true
+		}
+	}
+	return false
+}
+
+type roleContextKey struct{}
+
+// WithRole returns a copy of ctx carrying the role of the signed-in admin
+func WithRole(ctx context.Context, role Role) context.Context {
+	return context.WithValue(ctx, roleContextKey{}, role)
+}
+
+// RoleFromContext returns the role of the signed-in admin, or an empty role
+func RoleFromContext(ctx context.Context) Role {
+	role, _ := ctx.Value(roleContextKey{}).(Role)
+	return role
+}
+
+// Can reports whether the admin signed in on ctx has the permission
+func Can(ctx context.Context, permission Permission) bool {
+	return RoleFromContext(ctx).Can(permission)
+}
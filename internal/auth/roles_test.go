@@ -0,0 +1,52 @@
+package auth
+
+import (
+	"context"
+	"testing"
+)
+
+func TestRoleCan(t *testing.T) {
+	tests := []struct {
+		role       Role
+		permission Permission
+		expected   bool
+	}{
+		{RoleOwner, PermManageAdmins, true},
+		{RoleOwner, PermDelete, true},
+		{RoleEditor, PermEdit, true},
+		{RoleEditor, PermDelete, false},
+		{RoleEditor, PermManageAdmins, false},
+		{RoleViewer, PermReports, true},
+		{RoleViewer, PermEdit, false},
+		{RoleViewer, PermCheckIn, false},
+		{RoleEditor, PermCheckIn, true},
+		{RoleCheckIn, PermCheckIn, true},
+		{RoleCheckIn, PermAccount, true},
+		{RoleCheckIn, PermView, false},
+		{RoleCheckIn, PermReports, false},
+		{Role(""), PermView, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.role)+"/"+string(tt.permission), func(t *testing.T) {
+			if result := tt.role.Can(tt.permission); result != tt.expected {
+				t.Errorf("Expected %v but got %v", tt.expected, result)
+			}
+		})
+	}
+}
+
+func TestRoleFromContext(t *testing.T) {
+	ctx := context.Background()
+	if Can(ctx, PermView) {
+		t.Errorf("Expected no permissions without a role in the context")
+	}
+
+	ctx = WithRole(ctx, RoleEditor)
+	if RoleFromContext(ctx) != RoleEditor {
+		t.Errorf("Expected role %q but got %q", RoleEditor, RoleFromContext(ctx))
+	}
+	if !Can(ctx, PermEdit) {
+		t.Errorf("Expected editor in context to have edit permission")
+	}
+}
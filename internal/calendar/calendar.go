@@ -0,0 +1,161 @@
+package calendar
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// TimeZone is the time zone all event times are expressed in
+const TimeZone = "Europe/Bucharest"
+
+// bucharestTimeZone is the VTIMEZONE definition for Europe/Bucharest (EET/EEST)
+// DST starts on the last Sunday of March at 03:00 and ends on the last Sunday of October at 04:00
+const bucharestTimeZone = `BEGIN:VTIMEZONE
+TZID:Europe/Bucharest
+X-LIC-LOCATION:Europe/Bucharest
+BEGIN:DAYLIGHT
+TZOFFSETFROM:+0200
+TZOFFSETTO:+0300
+TZNAME:EEST
+DTSTART:19700329T030000
+RRULE:FREQ=YEARLY;BYMONTH=3;BYDAY=-1SU
+END:DAYLIGHT
+BEGIN:STANDARD
+TZOFFSETFROM:+0300
+TZOFFSETTO:+0200
+TZNAME:EET
+DTSTART:19701025T040000
+RRULE:FREQ=YEARLY;BYMONTH=10;BYDAY=-1SU
+END:STANDARD
+END:VTIMEZONE`
+
+// Event holds the details needed to export an event to a calendar
+type Event struct {
+	UID         string
+	Summary     string
+	Description string
+	Location    string
+	Start       time.Time
+	End         time.Time
+}
+
+// Links holds the "add to calendar" links shown to guests
+type Links struct {
+	Google  string
+	Outlook string
+	ICS     string
+}
+
+// ICS renders the event as an iCalendar (RFC 5545) file with two reminders
+func (e Event) ICS(now time.Time) string {
+	var b strings.Builder
+
+	writeLine(&b, "BEGIN:VCALENDAR")
+	writeLine(&b, "VERSION:2.0")
+	writeLine(&b, "PRODID:-//Evite//RSVP//EN")
+	writeLine(&b, "CALSCALE:GREGORIAN")
+	writeLine(&b, "METHOD:PUBLISH")
+	for _, line := range strings.Split(bucharestTimeZone, "\n") {
+		writeLine(&b, line)
+	}
+	writeLine(&b, "BEGIN:VEVENT")
+	writeLine(&b, "UID:"+e.UID)
+	writeLine(&b, "DTSTAMP:"+now.UTC().Format("20060102T150405Z"))
+	writeLine(&b, "DTSTART;TZID="+TimeZone+":"+localTime(e.Start).Format("20060102T150405"))
+	writeLine(&b, "DTEND;TZID="+TimeZone+":"+localTime(e.End).Format("20060102T150405"))
+	writeLine(&b, "SUMMARY:"+escapeText(e.Summary))
+	if e.Description != "" {
+		writeLine(&b, "DESCRIPTION:"+escapeText(e.Description))
+	}
+	if e.Location != "" {
+		writeLine(&b, "LOCATION:"+escapeText(e.Location))
+	}
+	writeAlarm(&b, "-P1D", e.Summary)
+	writeAlarm(&b, "-PT2H", e.Summary)
+	writeLine(&b, "END:VEVENT")
+	writeLine(&b, "END:VCALENDAR")
+
+	return b.String()
+}
+
+// GoogleURL returns a Google Calendar link that pre-fills the event
+func (e Event) GoogleURL() string {
+	params := url.Values{}
+	params.Set("action", "TEMPLATE")
+	params.Set("text", e.Summary)
+	params.Set("dates", e.Start.UTC().Format("20060102T150405Z")+"/"+e.End.UTC().Format("20060102T150405Z"))
+	params.Set("details", e.Description)
+	params.Set("location", e.Location)
+	params.Set("ctz", TimeZone)
+	return "https://calendar.google.com/calendar/render?" + params.Encode()
+}
+
+// OutlookURL returns an Outlook.com link that pre-fills the event
+func (e Event) OutlookURL() string {
+	params := url.Values{}
+	params.Set("path", "/calendar/action/compose")
+	params.Set("rru", "addevent")
+	params.Set("subject", e.Summary)
+	params.Set("startdt", e.Start.UTC().Format(time.RFC3339))
+	params.Set("enddt", e.End.UTC().Format(time.RFC3339))
+	params.Set("body", e.Description)
+	params.Set("location", e.Location)
+	return "https://outlook.live.com/calendar/0/deeplink/compose?" + params.Encode()
+}
+
+// writeAlarm writes a display reminder triggered at the given offset before the event
+func writeAlarm(b *strings.Builder, trigger, summary string) {
+	writeLine(b, "BEGIN:VALARM")
+	writeLine(b, "ACTION:DISPLAY")
+	writeLine(b, "TRIGGER:"+trigger)
+	writeLine(b, "DESCRIPTION:"+escapeText(summary))
+	writeLine(b, "END:VALARM")
+}
+
+// writeLine writes a content line folded at 75 octets and terminated with CRLF
+func writeLine(b *strings.Builder, line string) {
+	// Continuation lines start with a space, which counts towards the limit
+	maxLen := 75
+	for len(line) > maxLen {
+		cut := maxLen
+		// Don't split a multi-byte UTF-8 character
+		for cut > 0 && !isRuneStart(line[cut]) {
+			cut--
+		}
+		b.WriteString(line[:cut])
+		b.WriteString("\r\n ")
+		line = line[cut:]
+		maxLen = 74
+	}
+	b.WriteString(line)
+	b.WriteString("\r\n")
+}
+
+// isRuneStart reports whether c is the first byte of a UTF-8 encoded character
+func isRuneStart(c byte) bool {
+	return c&0xC0 != 0x80
+}
+
+// escapeText escapes a TEXT value according to RFC 5545
+func escapeText(text string) string {
+	replacer := strings.NewReplacer(
+		`\`, `\\`,
+		";", `\;`,
+		",", `\,`,
+		"\r\n", `\n`,
+		"\n", `\n`,
+	)
+	return replacer.Replace(text)
+}
+
+// localTime converts t to Europe/Bucharest, keeping t unchanged if tzdata is unavailable
+func localTime(t time.Time) time.Time {
+	loc, err := time.LoadLocation(TimeZone)
+	if err != nil {
+		fmt.Printf("Warning: failed to load %s time zone: %v\n", TimeZone, err)
+		return t
+	}
+	return t.In(loc)
+}
@@ -0,0 +1,82 @@
+package calendar
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestICS(t *testing.T) {
+	start := time.Date(2026, 4, 19, 11, 0, 0, 0, time.UTC)
+	event := Event{
+		UID:         "event-1@example.com",
+		Summary:     "Botez; Anya, Maria",
+		Description: "Biserica: Strada Panselelor 31\nRestaurant: Splaiul Unirii 9D",
+		Location:    "Biserica Apărătorii Patriei I, Strada Panselelor 31, București",
+		Start:       start,
+		End:         start.Add(8 * time.Hour),
+	}
+
+	ics := event.ICS(time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC))
+
+	expected := []string{
+		"BEGIN:VCALENDAR\r\n",
+		"TZID:Europe/Bucharest\r\n",
+		"DTSTAMP:20260101T000000Z\r\n",
+		"DTSTART;TZID=Europe/Bucharest:20260419T140000\r\n",
+		"DTEND;TZID=Europe/Bucharest:20260419T220000\r\n",
+		`SUMMARY:Botez\; Anya\, Maria`,
+		`DESCRIPTION:Biserica: Strada Panselelor 31\nRestaurant: Splaiul Unirii 9D`,
+		"TRIGGER:-P1D\r\n",
+		"TRIGGER:-PT2H\r\n",
+		"END:VCALENDAR\r\n",
+	}
+	for _, want := range expected {
+		if !strings.Contains(ics, want) {
+			t.Errorf("Expected ICS to contain %q, got:\n%s", want, ics)
+		}
+	}
+
+	for _, line := range strings.Split(strings.TrimSuffix(ics, "\r\n"), "\r\n") {
+		if len(line) > 75 {
+			t.Errorf("Line exceeds 75 octets (%d): %q", len(line), line)
+		}
+	}
+}
+
+func TestEscapeText(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{
+			name:     "Plain text",
+			input:    "Botez",
+			expected: "Botez",
+		},
+		{
+			name:     "Commas and semicolons",
+			input:    "a, b; c",
+			expected: `a\, b\; c`,
+		},
+		{
+			name:     "Backslash",
+			input:    `a\b`,
+			expected: `a\\b`,
+		},
+		{
+			name:     "Newlines",
+			input:    "a\nb\r\nc",
+			expected: `a\nb\nc`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if result := escapeText(tt.input); result != tt.expected {
+				t.Errorf("For input %q, expected %q but got %q", tt.input, tt.expected, result)
+			}
+		})
+	}
+}
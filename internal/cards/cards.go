@@ -0,0 +1,176 @@
+package cards
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	"strings"
+	"sync"
+
+	"github.com/AlexTLDR/evite/internal/qr"
+	xdraw "golang.org/x/image/draw"
+	"golang.org/x/image/font"
+	"golang.org/x/image/font/gofont/gobold"
+	"golang.org/x/image/font/gofont/goregular"
+	"golang.org/x/image/font/opentype"
+	"golang.org/x/image/math/fixed"
+)
+
+// PrintHeight is the height in pixels of rendered cards; an A5 card printed at about 260 DPI
+const PrintHeight = 2160
+
+// Layout proportions, relative to the card width (sizes) or height (positions)
+const (
+	contentWidth = 0.52 // share of the width the text may use, clear of the border decorations
+	contentTop   = 0.19 // where the guest name starts
+	qrSize       = 0.22 // QR code side
+	qrTop        = 0.55 // the QR code starts here, or lower if the text runs longer
+	nameSize     = 0.055
+	titleSize    = 0.045
+	detailSize   = 0.030
+	footerSize   = 0.026
+	lineSpacing  = 1.35
+)
+
+var (
+	nameColor   = color.RGBA{R: 0xc6, G: 0x28, B: 0x28, A: 0xff}
+	textColor   = color.RGBA{R: 0x3a, G: 0x3a, B: 0x3a, A: 0xff}
+	footerColor = color.RGBA{R: 0x5a, G: 0x5a, B: 0x5a, A: 0xff}
+)
+
+// Card holds the personalized content printed on an invitation card
+type Card struct {
+	GuestName string
+	Title     string
+	// Details are the event details (date, venues, deadline), one paragraph each
+	Details []string
+	// Link is encoded in the QR code
+	Link string
+	// Footer lines are printed below the QR code (e.g. how to use the invitation code)
+	Footer []string
+}
+
+// fonts holds the parsed typefaces used on cards
+type fonts struct {
+	regular *opentype.Font
+	bold    *opentype.Font
+}
+
+// loadFonts parses the embedded Go fonts once; they cover the Romanian diacritics
+var loadFonts = sync.OnceValues(func() (*fonts, error) {
+	regular, err := opentype.Parse(goregular.TTF)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse regular font: %w", err)
+	}
+	bold, err := opentype.Parse(gobold.TTF)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse bold font: %w", err)
+	}
+	return &fonts{regular: regular, bold: bold}, nil
+})
+
+// face returns a font face of the given pixel size
+func face(f *opentype.Font, size float64) (font.Face, error) {
+	return opentype.NewFace(f, &opentype.FaceOptions{Size: size, DPI: 72, Hinting: font.HintingFull})
+}
+
+// Render draws the card onto the background, scaled to PrintHeight
+func Render(background image.Image, card Card) (*image.RGBA, error) {
+	fonts, err := loadFonts()
+	if err != nil {
+		return nil, err
+	}
+
+	bounds := background.Bounds()
+	height := PrintHeight
+	width := bounds.Dx() * height / bounds.Dy()
+	dst := image.NewRGBA(image.Rect(0, 0, width, height))
+	xdraw.CatmullRom.Scale(dst, dst.Bounds(), background, bounds, draw.Src, nil)
+
+	w, h := float64(width), float64(height)
+	maxWidth := int(w * contentWidth)
+	y := int(h * contentTop)
+
+	blocks := []struct {
+		font  *opentype.Font
+		size  float64
+		color color.Color
+		text  []string
+	}{
+		{fonts.bold, w * nameSize, nameColor, []string{card.GuestName}},
+		{fonts.bold, w * titleSize, textColor, []string{card.Title}},
+		{fonts.regular, w * detailSize, textColor, card.Details},
+	}
+	for _, block := range blocks {
+		f, err := face(block.font, block.size)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create font face: %w", err)
+		}
+		for _, paragraph := range block.text {
+			y = drawParagraph(dst, f, block.color, paragraph, width/2, maxWidth, y, block.size)
+		}
+		y += int(block.size * 0.5)
+		f.Close()
+	}
+
+	// The QR code goes below the text, but never over it
+	side := int(w * qrSize)
+	margin := side / 12
+	top := max(int(h*qrTop), y+2*margin)
+	code, err := qr.Image(card.Link, side)
+	if err != nil {
+		return nil, err
+	}
+	left := (width - side) / 2
+	draw.Draw(dst, image.Rect(left-margin, top-margin, left+side+margin, top+side+margin), image.White, image.Point{}, draw.Src)
+	draw.Draw(dst, image.Rect(left, top, left+side, top+side), code, image.Point{}, draw.Src)
+
+	f, err := face(fonts.regular, w*footerSize)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create font face: %w", err)
+	}
+	defer f.Close()
+	y = top + side + margin + int(w*footerSize*0.5)
+	for _, line := range card.Footer {
+		y = drawParagraph(dst, f, footerColor, line, width/2, maxWidth, y, w*footerSize)
+	}
+
+	return dst, nil
+}
+
+// drawParagraph draws text centered on centerX, wrapped to maxWidth, starting at top y
+// Returns the y position below the last line
+func drawParagraph(dst draw.Image, f font.Face, c color.Color, text string, centerX, maxWidth, y int, size float64) int {
+	d := &font.Drawer{Dst: dst, Src: image.NewUniform(c), Face: f}
+	lineHeight := int(size * lineSpacing)
+	for _, line := range wrap(d, text, maxWidth) {
+		y += lineHeight
+		x := centerX - d.MeasureString(line).Ceil()/2
+		d.Dot = fixed.P(x, y)
+		d.DrawString(line)
+	}
+	return y
+}
+
+// wrap splits text into lines no wider than maxWidth, breaking at spaces
+// A single word wider than maxWidth gets a line of its own
+func wrap(d *font.Drawer, text string, maxWidth int) []string {
+	var lines []string
+	line := ""
+	for _, word := range strings.Fields(text) {
+		candidate := word
+		if line != "" {
+			candidate = line + " " + word
+		}
+		if line != "" && d.MeasureString(candidate).Ceil() > maxWidth {
+			lines = append(lines, line)
+			candidate = word
+		}
+		line = candidate
+	}
+	if line != "" {
+		lines = append(lines, line)
+	}
+	return lines
+}
@@ -0,0 +1,138 @@
+package cards
+
+import (
+	"image"
+	"image/color"
+	"image/draw"
+	"strings"
+	"testing"
+
+	"golang.org/x/image/font"
+	"golang.org/x/image/font/basicfont"
+)
+
+// plainBackground returns a uniformly colored background of the given size
+func plainBackground(width, height int) image.Image {
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+	draw.Draw(img, img.Bounds(), image.NewUniform(color.RGBA{R: 0xfa, G: 0xeb, B: 0xe3, A: 0xff}), image.Point{}, draw.Src)
+	return img
+}
+
+// changedPixels counts pixels in the rectangle that differ from the background color
+func changedPixels(img *image.RGBA, rect image.Rectangle) int {
+	background := color.RGBAModel.Convert(color.RGBA{R: 0xfa, G: 0xeb, B: 0xe3, A: 0xff})
+	count := 0
+	for y := rect.Min.Y; y < rect.Max.Y; y++ {
+		for x := rect.Min.X; x < rect.Max.X; x++ {
+			if img.RGBAAt(x, y) != background {
+				count++
+			}
+		}
+	}
+	return count
+}
+
+func TestRender(t *testing.T) {
+	card := Card{
+		GuestName: "Ștefan Țăranu",
+		Title:     "Botez Anya-Maria",
+		Details:   []string{"19 Aprilie 2026, 14:00", "Biserica Apărătorii Patriei I, Strada Panselelor 31, București"},
+		Link:      "https://example.com/rsvp/0123456789abcdef0123456789abcdef",
+		Footer:    []string{"Cod: ABC234"},
+	}
+
+	img, err := Render(plainBackground(506, 720), card)
+	if err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+
+	bounds := img.Bounds()
+	if bounds.Dy() != PrintHeight || bounds.Dx() != 506*PrintHeight/720 {
+		t.Errorf("Render() size = %dx%d, want %dx%d", bounds.Dx(), bounds.Dy(), 506*PrintHeight/720, PrintHeight)
+	}
+
+	// The name is drawn near the top and the QR code in the lower half
+	height := float64(bounds.Dy())
+	nameTop := int(height * contentTop)
+	top := image.Rect(0, nameTop, bounds.Dx(), nameTop+200)
+	if changedPixels(img, top) == 0 {
+		t.Error("Expected the guest name to be drawn near the top of the card")
+	}
+	center := bounds.Dx() / 2
+	codeTop := int(height * qrTop)
+	qr := image.Rect(center-50, codeTop, center+50, codeTop+100)
+	if changedPixels(img, qr) == 0 {
+		t.Error("Expected the QR code to be drawn in the lower half of the card")
+	}
+}
+
+func TestWrap(t *testing.T) {
+	// basicfont.Face7x13 is monospaced: every character is 7 pixels wide
+	d := &font.Drawer{Face: basicfont.Face7x13}
+
+	tests := []struct {
+		name     string
+		text     string
+		maxWidth int
+		expected []string
+	}{
+		{
+			name:     "Fits on one line",
+			text:     "Botez Anya",
+			maxWidth: 100,
+			expected: []string{"Botez Anya"},
+		},
+		{
+			name:     "Wraps at spaces",
+			text:     "Strada Panselelor 31 Bucuresti",
+			maxWidth: 7 * 20,
+			expected: []string{"Strada Panselelor 31", "Bucuresti"},
+		},
+		{
+			name:     "Long word on its own line",
+			text:     "a Supercalifragilistic b",
+			maxWidth: 7 * 5,
+			expected: []string{"a", "Supercalifragilistic", "b"},
+		},
+		{
+			name:     "Empty",
+			text:     "   ",
+			maxWidth: 100,
+			expected: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := wrap(d, tt.text, tt.maxWidth)
+			if strings.Join(got, "|") != strings.Join(tt.expected, "|") || len(got) != len(tt.expected) {
+				t.Errorf("wrap(%q, %d) = %q, want %q", tt.text, tt.maxWidth, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestRenderPreview(t *testing.T) {
+	preview := Preview{
+		Title:    "Invitație pentru Ștefan",
+		Subtitle: "Botez Anya-Maria",
+		Lines:    []string{"19 Aprilie 2026, 14:00"},
+	}
+
+	img, err := RenderPreview(plainBackground(506, 720), preview)
+	if err != nil {
+		t.Fatalf("RenderPreview() error = %v", err)
+	}
+
+	bounds := img.Bounds()
+	if bounds.Dx() != PreviewWidth || bounds.Dy() != PreviewHeight {
+		t.Errorf("RenderPreview() size = %dx%d, want %dx%d", bounds.Dx(), bounds.Dy(), PreviewWidth, PreviewHeight)
+	}
+
+	// The title is drawn right of the card
+	cardWidth := 506 * PreviewHeight / 720
+	text := image.Rect(cardWidth, PreviewHeight/5, PreviewWidth, PreviewHeight/5+100)
+	if changedPixels(img, text) == 0 {
+		t.Error("Expected the title to be drawn right of the card")
+	}
+}
@@ -0,0 +1,75 @@
+package cards
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+
+	xdraw "golang.org/x/image/draw"
+)
+
+// Link preview images use the size recommended for Open Graph and Twitter cards
+const (
+	PreviewWidth  = 1200
+	PreviewHeight = 630
+)
+
+// Preview holds the text shown on a link preview image
+type Preview struct {
+	Title    string
+	Subtitle string
+	Lines    []string
+}
+
+// RenderPreview draws a landscape link preview: the card design on the left and the text on the right
+// The canvas is filled with the color at the center of the design so the two blend together
+func RenderPreview(background image.Image, preview Preview) (*image.RGBA, error) {
+	fonts, err := loadFonts()
+	if err != nil {
+		return nil, err
+	}
+
+	dst := image.NewRGBA(image.Rect(0, 0, PreviewWidth, PreviewHeight))
+	bounds := background.Bounds()
+	fill := color.RGBAModel.Convert(background.At(bounds.Min.X+bounds.Dx()/2, bounds.Min.Y+bounds.Dy()/2))
+	draw.Draw(dst, dst.Bounds(), image.NewUniform(fill), image.Point{}, draw.Src)
+
+	cardWidth := bounds.Dx() * PreviewHeight / bounds.Dy()
+	xdraw.CatmullRom.Scale(dst, image.Rect(0, 0, cardWidth, PreviewHeight), background, bounds, draw.Src, nil)
+
+	// Text is centered in the space right of the card
+	centerX := cardWidth + (PreviewWidth-cardWidth)/2
+	maxWidth := (PreviewWidth - cardWidth) * 85 / 100
+	y := PreviewHeight / 5
+
+	blocks := []struct {
+		bold  bool
+		size  float64
+		color color.Color
+		text  []string
+	}{
+		{true, 56, nameColor, []string{preview.Title}},
+		{true, 40, textColor, []string{preview.Subtitle}},
+		{false, 30, textColor, preview.Lines},
+	}
+	for _, block := range blocks {
+		typeface := fonts.regular
+		if block.bold {
+			typeface = fonts.bold
+		}
+		f, err := face(typeface, block.size)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create font face: %w", err)
+		}
+		for _, paragraph := range block.text {
+			if paragraph != "" {
+				y = drawParagraph(dst, f, block.color, paragraph, centerX, maxWidth, y, block.size)
+			}
+		}
+		y += int(block.size * 0.5)
+		f.Close()
+	}
+
+	return dst, nil
+}
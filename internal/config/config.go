@@ -1,10 +1,15 @@
 package config
 
 import (
+	"errors"
 	"fmt"
+	"net"
 	"os"
+	"strconv"
 	"strings"
 	"time"
+
+	"github.com/AlexTLDR/evite/internal/utils"
 )
 
 type Config struct {
@@ -15,19 +20,71 @@ type Config struct {
 	GoogleClientID     string
 	GoogleClientSecret string
 	GoogleRedirectURL  string
-	AdminEmails        []string
+
+	// Owners seeded on the first start, while the admins table is empty
+	AdminEmails []string
+
+	// Admin login provider: "google" or "oidc"
+	AuthProvider string
+
+	// Generic OpenID Connect (used when AuthProvider is "oidc")
+	OIDCIssuerURL    string
+	OIDCClientID     string
+	OIDCClientSecret string
+	OIDCRedirectURL  string
+	OIDCScopes       []string
+	OIDCEmailClaim   string
+	OIDCNameClaim    string
+
+	// SMTP (used for admin email login links)
+	SMTPHost     string
+	SMTPPort     string
+	SMTPUsername string
+	SMTPPassword string
+	SMTPFrom     string
 
 	// Session
-	SessionSecret string
+	SessionSecret      string
+	SessionIdleTimeout time.Duration
+	SessionMaxAge      time.Duration
 
 	// Event Details
+	EventName         string
 	EventDate         time.Time
+	EventDuration     time.Duration
 	RSVPDeadline      time.Time
 	ChurchName        string
 	ChurchAddress     string
 	RestaurantName    string
 	RestaurantAddress string
 
+	// Invitation links stop working this long after the event ends (0 keeps them valid)
+	LinkExpiryAfterEvent time.Duration
+
+	// Invitations whose response changed after this time are highlighted in the admin list (zero disables it)
+	HighlightChangesSince time.Time
+
+	// Archived invitations are permanently deleted this long after archiving (0 keeps them forever)
+	ArchivePurgeAfter time.Duration
+
+	// Catering prices per head (used for the catering cost estimate)
+	PriceAdultStandard float64
+	PriceAdultVegan    float64
+	PriceChild         float64
+	Currency           string
+
+	// Rate limits for public routes (requests per window; 0 disables the limit)
+	// RateLimitUnknownTokens counts requests with invitation tokens or codes that do not exist, per client IP
+	// RateLimitPerToken counts RSVP submissions, withdrawals and form starts per invitation
+	RateLimitPerIP         int
+	RateLimitUnknownTokens int
+	RateLimitPerToken      int
+	RateLimitWindow        time.Duration
+	RateLimitLockout       time.Duration
+
+	// Reverse proxies whose X-Forwarded-For header is trusted for client IPs (empty trusts none)
+	TrustedProxies []*net.IPNet
+
 	// App
 	BaseURL string
 }
@@ -38,12 +95,43 @@ func Load() (*Config, error) {
 		GoogleClientID:     getEnv("GOOGLE_CLIENT_ID", ""),
 		GoogleClientSecret: getEnv("GOOGLE_CLIENT_SECRET", ""),
 		GoogleRedirectURL:  getEnv("GOOGLE_REDIRECT_URL", ""),
-		SessionSecret:      getEnv("SESSION_SECRET", "change-me-in-production"),
+		AuthProvider:       getEnv("AUTH_PROVIDER", "google"),
+		OIDCIssuerURL:      getEnv("OIDC_ISSUER_URL", ""),
+		OIDCClientID:       getEnv("OIDC_CLIENT_ID", ""),
+		OIDCClientSecret:   getEnv("OIDC_CLIENT_SECRET", ""),
+		OIDCRedirectURL:    getEnv("OIDC_REDIRECT_URL", ""),
+		OIDCEmailClaim:     getEnv("OIDC_EMAIL_CLAIM", "email"),
+		OIDCNameClaim:      getEnv("OIDC_NAME_CLAIM", "name"),
+		SMTPHost:           getEnv("SMTP_HOST", ""),
+		SMTPPort:           getEnv("SMTP_PORT", "587"),
+		SMTPUsername:       getEnv("SMTP_USERNAME", ""),
+		SMTPPassword:       getEnv("SMTP_PASSWORD", ""),
+		SMTPFrom:           getEnv("SMTP_FROM", ""),
+		SessionSecret:      getEnv("SESSION_SECRET", ""),
 		BaseURL:            getEnv("BASE_URL", "http://localhost:8080"),
+		EventName:          getEnv("EVENT_NAME", "Botez Anya-Maria"),
 		ChurchName:         getEnv("CHURCH_NAME", ""),
 		ChurchAddress:      getEnv("CHURCH_ADDRESS", ""),
 		RestaurantName:     getEnv("RESTAURANT_NAME", ""),
 		RestaurantAddress:  getEnv("RESTAURANT_ADDRESS", ""),
+		Currency:           getEnv("CATERING_CURRENCY", "RON"),
+	}
+
+	// Parse catering prices
+	prices := []struct {
+		key   string
+		value *float64
+	}{
+		{"CATERING_PRICE_ADULT_STANDARD", &cfg.PriceAdultStandard},
+		{"CATERING_PRICE_ADULT_VEGAN", &cfg.PriceAdultVegan},
+		{"CATERING_PRICE_CHILD", &cfg.PriceChild},
+	}
+	for _, price := range prices {
+		value, err := strconv.ParseFloat(getEnv(price.key, "0"), 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s format: %w", price.key, err)
+		}
+		*price.value = value
 	}
 
 	// Parse admin emails
@@ -55,6 +143,24 @@ func Load() (*Config, error) {
 		}
 	}
 
+	// Validate the admin login provider
+	switch cfg.AuthProvider {
+	case "google":
+	case "oidc":
+		if cfg.OIDCIssuerURL == "" || cfg.OIDCClientID == "" {
+			return nil, fmt.Errorf("AUTH_PROVIDER=oidc requires OIDC_ISSUER_URL and OIDC_CLIENT_ID")
+		}
+	default:
+		return nil, fmt.Errorf("invalid AUTH_PROVIDER %q: must be google or oidc", cfg.AuthProvider)
+	}
+
+	// Parse OIDC scopes (the openid scope is always requested)
+	for _, scope := range strings.Split(getEnv("OIDC_SCOPES", "openid,email,profile"), ",") {
+		if scope = strings.TrimSpace(scope); scope != "" {
+			cfg.OIDCScopes = append(cfg.OIDCScopes, scope)
+		}
+	}
+
 	// Parse event date
 	eventDateStr := getEnv("EVENT_DATE", "2026-04-19T14:00:00")
 	eventDate, err := time.Parse(time.RFC3339, eventDateStr)
@@ -64,6 +170,13 @@ func Load() (*Config, error) {
 	loc, _ := time.LoadLocation("Europe/Bucharest")
 	cfg.EventDate = eventDate.In(loc)
 
+	// Parse event duration (used for calendar exports)
+	durationStr := getEnv("EVENT_DURATION", "8h")
+	cfg.EventDuration, err = time.ParseDuration(durationStr)
+	if err != nil {
+		return nil, fmt.Errorf("invalid EVENT_DURATION format: %w", err)
+	}
+
 	// Parse RSVP deadline
 	deadlineStr := getEnv("RSVP_DEADLINE", "2026-04-12T23:59:59")
 	deadline, err := time.Parse(time.RFC3339, deadlineStr)
@@ -72,6 +185,78 @@ func Load() (*Config, error) {
 	}
 	cfg.RSVPDeadline = deadline.In(loc)
 
+	// Parse invitation link expiry
+	cfg.LinkExpiryAfterEvent, err = time.ParseDuration(getEnv("LINK_EXPIRY_AFTER_EVENT", "0"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid LINK_EXPIRY_AFTER_EVENT format: %w", err)
+	}
+
+	// Parse the response change highlight date
+	// Accepts a plain date (the format of the admin list's date picker) or an RFC3339 time
+	if since := getEnv("HIGHLIGHT_CHANGES_SINCE", ""); since != "" {
+		changesSince, err := time.ParseInLocation("2006-01-02", since, loc)
+		if err != nil {
+			changesSince, err = time.Parse(time.RFC3339, since)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("invalid HIGHLIGHT_CHANGES_SINCE format (use 2006-01-02 or RFC3339): %w", err)
+		}
+		cfg.HighlightChangesSince = changesSince.In(loc)
+	}
+
+	// Parse the archive retention period
+	cfg.ArchivePurgeAfter, err = time.ParseDuration(getEnv("ARCHIVE_PURGE_AFTER", "0"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid ARCHIVE_PURGE_AFTER format: %w", err)
+	}
+
+	// Parse admin session timeouts
+	cfg.SessionIdleTimeout, err = time.ParseDuration(getEnv("SESSION_IDLE_TIMEOUT", "12h"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid SESSION_IDLE_TIMEOUT format: %w", err)
+	}
+	cfg.SessionMaxAge, err = time.ParseDuration(getEnv("SESSION_MAX_AGE", "168h"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid SESSION_MAX_AGE format: %w", err)
+	}
+	if err := checkSessionSecret(cfg.SessionSecret); err != nil {
+		if getEnv("ALLOW_INSECURE_SESSION_SECRET", "") != "true" {
+			return nil, fmt.Errorf("%w (set ALLOW_INSECURE_SESSION_SECRET=true to run anyway during development)", err)
+		}
+		fmt.Printf("Warning: %v; CSRF tokens and login links can be forged\n", err)
+	}
+
+	// Parse public rate limits
+	limits := []struct {
+		key          string
+		defaultValue string
+		value        *int
+	}{
+		{"RATE_LIMIT_PER_IP", "60", &cfg.RateLimitPerIP},
+		{"RATE_LIMIT_UNKNOWN_TOKENS", "10", &cfg.RateLimitUnknownTokens},
+		{"RATE_LIMIT_PER_TOKEN", "30", &cfg.RateLimitPerToken},
+	}
+	for _, limit := range limits {
+		*limit.value, err = strconv.Atoi(getEnv(limit.key, limit.defaultValue))
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s format: %w", limit.key, err)
+		}
+	}
+	cfg.RateLimitWindow, err = time.ParseDuration(getEnv("RATE_LIMIT_WINDOW", "1m"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid RATE_LIMIT_WINDOW format: %w", err)
+	}
+	cfg.RateLimitLockout, err = time.ParseDuration(getEnv("RATE_LIMIT_LOCKOUT", "15m"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid RATE_LIMIT_LOCKOUT format: %w", err)
+	}
+
+	// Parse trusted reverse proxies
+	cfg.TrustedProxies, err = utils.ParseTrustedProxies(getEnv("TRUSTED_PROXIES", ""))
+	if err != nil {
+		return nil, fmt.Errorf("invalid TRUSTED_PROXIES format: %w", err)
+	}
+
 	// Debug logging
 	fmt.Printf("CONFIG DEBUG: RSVP_DEADLINE string: %s\n", deadlineStr)
 	fmt.Printf("CONFIG DEBUG: RSVP_DEADLINE parsed: %v\n", cfg.RSVPDeadline)
@@ -80,6 +265,23 @@ func Load() (*Config, error) {
 	return cfg, nil
 }
 
+// minSessionSecretLength is the shortest SESSION_SECRET accepted outside development
+const minSessionSecretLength = 32
+
+// checkSessionSecret rejects empty, placeholder and short session secrets,
+// since the secret signs CSRF tokens and emailed login links
+func checkSessionSecret(secret string) error {
+	switch {
+	case secret == "":
+		return errors.New("SESSION_SECRET is empty")
+	case secret == "change-me-in-production" || secret == "change-me-to-a-random-string-in-production":
+		return errors.New("SESSION_SECRET is still the example value")
+	case len(secret) < minSessionSecretLength:
+		return fmt.Errorf("SESSION_SECRET must be at least %d characters", minSessionSecretLength)
+	}
+	return nil
+}
+
 func getEnv(key, defaultValue string) string {
 	if value := os.Getenv(key); value != "" {
 		return value
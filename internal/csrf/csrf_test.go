@@ -0,0 +1,80 @@
+package csrf
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+func TestMiddleware(t *testing.T) {
+	var seenToken string
+	handler := Middleware("secret", false, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		seenToken = Token(r.Context())
+	}))
+
+	// A first GET sets the cookie and exposes the matching token
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+	cookies := rec.Result().Cookies()
+	if len(cookies) != 1 || cookies[0].Name != CookieName {
+		t.Fatalf("Expected a %s cookie but got %v", CookieName, cookies)
+	}
+	cookie := cookies[0]
+	token := seenToken
+	if token == "" {
+		t.Fatalf("Expected a token in the request context")
+	}
+
+	tests := []struct {
+		name     string
+		cookie   *http.Cookie
+		field    string
+		header   string
+		expected int
+	}{
+		{"valid form token", cookie, token, "", http.StatusOK},
+		{"valid header token", cookie, "", token, http.StatusOK},
+		{"missing token", cookie, "", "", http.StatusForbidden},
+		{"wrong token", cookie, "forged", "", http.StatusForbidden},
+		{"missing cookie", nil, token, "", http.StatusForbidden},
+		{"other cookie", &http.Cookie{Name: CookieName, Value: "attacker"}, token, "", http.StatusForbidden},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			form := url.Values{}
+			if tt.field != "" {
+				form.Set(FieldName, tt.field)
+			}
+			req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(form.Encode()))
+			req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+			if tt.header != "" {
+				req.Header.Set(HeaderName, tt.header)
+			}
+			if tt.cookie != nil {
+				req.AddCookie(tt.cookie)
+			}
+
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+			if rec.Code != tt.expected {
+				t.Errorf("Expected status %d but got %d", tt.expected, rec.Code)
+			}
+		})
+	}
+}
+
+func TestMiddlewareKeepsExistingCookie(t *testing.T) {
+	handler := Middleware("secret", false, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.AddCookie(&http.Cookie{Name: CookieName, Value: "existing"})
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+
+	if len(rec.Result().Cookies()) != 0 {
+		t.Errorf("Expected the existing cookie to be reused")
+	}
+}
@@ -0,0 +1,64 @@
+package database
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+)
+
+// Kinds of abuse recorded for admins to review
+const (
+	AbuseRateLimitIP    = "rate_limit_ip"
+	AbuseRateLimitToken = "rate_limit_token"
+	AbuseRateLimitWrite = "rate_limit_write"
+	AbuseHoneypot       = "honeypot"
+)
+
+// AbuseEvent is a rate-limit lockout or a blocked bot submission
+// Key is the locked-out IP or invitation token
+type AbuseEvent struct {
+	ID        int64
+	Kind      string
+	Key       sql.NullString
+	IP        sql.NullString
+	Path      sql.NullString
+	UserAgent sql.NullString
+	CreatedAt time.Time
+}
+
+// CreateAbuseEvent stores an abuse event
+func (db *DB) CreateAbuseEvent(event *AbuseEvent) error {
+	_, err := db.Exec(
+		`INSERT INTO abuse_log (kind, key, ip, path, user_agent) VALUES ($1, $2, $3, $4, $5)`,
+		event.Kind, event.Key, event.IP, event.Path, event.UserAgent,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create abuse event: %w", err)
+	}
+	return nil
+}
+
+// GetAbuseEvents retrieves the most recent abuse events, newest first
+func (db *DB) GetAbuseEvents(limit int) ([]*AbuseEvent, error) {
+	rows, err := db.Query(
+		`SELECT id, kind, key, ip, path, user_agent, created_at
+		 FROM abuse_log ORDER BY created_at DESC, id DESC LIMIT $1`,
+		limit,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get abuse events: %w", err)
+	}
+	defer rows.Close()
+
+	var events []*AbuseEvent
+	for rows.Next() {
+		event := &AbuseEvent{}
+		err := rows.Scan(&event.ID, &event.Kind, &event.Key, &event.IP, &event.Path, &event.UserAgent, &event.CreatedAt)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan abuse event: %w", err)
+		}
+		events = append(events, event)
+	}
+
+	return events, nil
+}
@@ -0,0 +1,66 @@
+package database
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+)
+
+// CreateAdminLoginLink records an email login request
+// tokenHash is empty when the address is not an admin and no link was sent
+func (db *DB) CreateAdminLoginLink(email, tokenHash, ip string, expiresAt time.Time) error {
+	_, err := db.Exec(
+		`INSERT INTO admin_login_links (email, token_hash, ip, expires_at) VALUES ($1, $2, $3, $4)`,
+		normalizeEmail(email), sql.NullString{String: tokenHash, Valid: tokenHash != ""}, ip, expiresAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create admin login link: %w", err)
+	}
+	return nil
+}
+
+// CountAdminLoginLinks counts the login requests made since a time for an email and for an IP
+func (db *DB) CountAdminLoginLinks(email, ip string, since time.Time) (byEmail int, byIP int, err error) {
+	err = db.QueryRow(
+		`SELECT
+			COUNT(*) FILTER (WHERE email = $1),
+			COUNT(*) FILTER (WHERE ip = $2)
+		 FROM admin_login_links
+		 WHERE created_at >= $3 AND (email = $1 OR ip = $2)`,
+		normalizeEmail(email), ip, since,
+	).Scan(&byEmail, &byIP)
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to count admin login links: %w", err)
+	}
+	return byEmail, byIP, nil
+}
+
+// ConsumeAdminLoginLink marks a login link as used and returns its email
+// Returns an empty string if the link doesn't exist, has expired or was already used
+func (db *DB) ConsumeAdminLoginLink(tokenHash string) (string, error) {
+	var email string
+	err := db.QueryRow(
+		`UPDATE admin_login_links SET used_at = $1
+		 WHERE token_hash = $2 AND used_at IS NULL AND expires_at > $1
+		 RETURNING email`,
+		time.Now(), tokenHash,
+	).Scan(&email)
+
+	if err == sql.ErrNoRows {
+		return "", nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to consume admin login link: %w", err)
+	}
+
+	return email, nil
+}
+
+// DeleteAdminLoginLinksBefore removes login requests older than a time
+func (db *DB) DeleteAdminLoginLinksBefore(before time.Time) error {
+	_, err := db.Exec(`DELETE FROM admin_login_links WHERE created_at < $1`, before)
+	if err != nil {
+		return fmt.Errorf("failed to delete admin login links: %w", err)
+	}
+	return nil
+}
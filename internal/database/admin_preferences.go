@@ -0,0 +1,40 @@
+package database
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+)
+
+// GetAdminLanguage retrieves the preferred admin UI language for an email
+// Returns an empty string if the admin has not chosen a language yet
+func (db *DB) GetAdminLanguage(email string) (string, error) {
+	var language string
+	err := db.QueryRow(
+		`SELECT language FROM admin_preferences WHERE email = $1`,
+		email,
+	).Scan(&language)
+
+	if err == sql.ErrNoRows {
+		return "", nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to get admin language: %w", err)
+	}
+
+	return language, nil
+}
+
+// SetAdminLanguage stores the preferred admin UI language for an email
+func (db *DB) SetAdminLanguage(email, language string) error {
+	_, err := db.Exec(
+		`INSERT INTO admin_preferences (email, language, updated_at)
+		 VALUES ($1, $2, $3)
+		 ON CONFLICT (email) DO UPDATE SET language = EXCLUDED.language, updated_at = EXCLUDED.updated_at`,
+		email, language, time.Now(),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to set admin language: %w", err)
+	}
+	return nil
+}
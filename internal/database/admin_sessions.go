@@ -0,0 +1,134 @@
+package database
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+)
+
+// CreateAdminSession stores a new admin session and sets its ID
+func (db *DB) CreateAdminSession(session *AdminSession) error {
+	err := db.QueryRow(
+		`INSERT INTO admin_sessions (token_hash, email, name, user_agent, ip, created_at, last_seen_at, expires_at)
+		 VALUES ($1, $2, $3, $4, $5, $6, $7, $8) RETURNING id`,
+		session.TokenHash, normalizeEmail(session.Email), session.Name, session.UserAgent, session.IP,
+		session.CreatedAt, session.LastSeenAt, session.ExpiresAt,
+	).Scan(&session.ID)
+	if err != nil {
+		return fmt.Errorf("failed to create admin session: %w", err)
+	}
+	return nil
+}
+
+// GetAdminSession retrieves a session by token hash
+// Returns nil without an error if the session doesn't exist
+func (db *DB) GetAdminSession(tokenHash string) (*AdminSession, error) {
+	session := &AdminSession{}
+	err := db.QueryRow(
+		`SELECT id, token_hash, email, name, user_agent, ip, created_at, last_seen_at, expires_at
+		 FROM admin_sessions WHERE token_hash = $1`,
+		tokenHash,
+	).Scan(&session.ID, &session.TokenHash, &session.Email, &session.Name, &session.UserAgent, &session.IP,
+		&session.CreatedAt, &session.LastSeenAt, &session.ExpiresAt)
+
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get admin session: %w", err)
+	}
+
+	return session, nil
+}
+
+// GetActiveAdminSessions retrieves sessions that have neither expired nor been idle since idleSince
+// If email is not empty, only the sessions of that admin are returned
+func (db *DB) GetActiveAdminSessions(email string, idleSince, now time.Time) ([]*AdminSession, error) {
+	rows, err := db.Query(
+		`SELECT id, token_hash, email, name, user_agent, ip, created_at, last_seen_at, expires_at
+		 FROM admin_sessions
+		 WHERE expires_at > $1 AND last_seen_at > $2 AND ($3 = '' OR email = $3)
+		 ORDER BY last_seen_at DESC`,
+		now, idleSince, normalizeEmail(email),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get admin sessions: %w", err)
+	}
+	defer rows.Close()
+
+	var sessions []*AdminSession
+	for rows.Next() {
+		session := &AdminSession{}
+		err := rows.Scan(&session.ID, &session.TokenHash, &session.Email, &session.Name, &session.UserAgent, &session.IP,
+			&session.CreatedAt, &session.LastSeenAt, &session.ExpiresAt)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan admin session: %w", err)
+		}
+		sessions = append(sessions, session)
+	}
+
+	return sessions, nil
+}
+
+// TouchAdminSession records activity on a session
+func (db *DB) TouchAdminSession(id int64, ip string, now time.Time) error {
+	_, err := db.Exec(
+		`UPDATE admin_sessions SET last_seen_at = $1, ip = $2 WHERE id = $3`,
+		now, ip, id,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to update admin session: %w", err)
+	}
+	return nil
+}
+
+// GetAdminSessionByID retrieves a session by ID
+// Returns nil without an error if the session doesn't exist
+func (db *DB) GetAdminSessionByID(id int64) (*AdminSession, error) {
+	session := &AdminSession{}
+	err := db.QueryRow(
+		`SELECT id, token_hash, email, name, user_agent, ip, created_at, last_seen_at, expires_at
+		 FROM admin_sessions WHERE id = $1`,
+		id,
+	).Scan(&session.ID, &session.TokenHash, &session.Email, &session.Name, &session.UserAgent, &session.IP,
+		&session.CreatedAt, &session.LastSeenAt, &session.ExpiresAt)
+
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get admin session: %w", err)
+	}
+
+	return session, nil
+}
+
+// DeleteAdminSession signs a session out
+func (db *DB) DeleteAdminSession(id int64) error {
+	_, err := db.Exec(`DELETE FROM admin_sessions WHERE id = $1`, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete admin session: %w", err)
+	}
+	return nil
+}
+
+// DeleteAdminSessionsByEmail signs an admin out everywhere
+func (db *DB) DeleteAdminSessionsByEmail(email string) error {
+	_, err := db.Exec(`DELETE FROM admin_sessions WHERE email = $1`, normalizeEmail(email))
+	if err != nil {
+		return fmt.Errorf("failed to delete admin sessions: %w", err)
+	}
+	return nil
+}
+
+// DeleteExpiredAdminSessions removes sessions past their absolute or idle timeout
+func (db *DB) DeleteExpiredAdminSessions(idleSince, now time.Time) error {
+	_, err := db.Exec(
+		`DELETE FROM admin_sessions WHERE expires_at <= $1 OR last_seen_at <= $2`,
+		now, idleSince,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to delete expired admin sessions: %w", err)
+	}
+	return nil
+}
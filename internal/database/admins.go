@@ -0,0 +1,226 @@
+package database
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// ErrLastOwner is returned when a change would leave no owner admin
+var ErrLastOwner = errors.New("cannot remove the last owner")
+
+// normalizeEmail lowercases and trims an email so lookups are case-insensitive
+func normalizeEmail(email string) string {
+	return strings.ToLower(strings.TrimSpace(email))
+}
+
+// SeedOwners adds the given emails as owners when the admins table is empty
+// Used once to bootstrap the admins table from the ADMIN_EMAILS environment variable;
+// after that admins are managed in the database only, so removed owners stay removed
+// Returns whether the owners were seeded
+func (db *DB) SeedOwners(emails []string) (bool, error) {
+	tx, err := db.Begin()
+	if err != nil {
+		return false, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	// Serialize concurrent startups so only one of them seeds
+	if _, err := tx.Exec(`LOCK TABLE admins IN EXCLUSIVE MODE`); err != nil {
+		return false, fmt.Errorf("failed to lock admins: %w", err)
+	}
+
+	var exists bool
+	if err := tx.QueryRow(`SELECT EXISTS (SELECT 1 FROM admins)`).Scan(&exists); err != nil {
+		return false, fmt.Errorf("failed to count admins: %w", err)
+	}
+	if exists {
+		return false, nil
+	}
+
+	seeded := false
+	for _, email := range emails {
+		email = normalizeEmail(email)
+		if email == "" {
+			continue
+		}
+		_, err := tx.Exec(
+			`INSERT INTO admins (email, role, invited_by) VALUES ($1, 'owner', 'ADMIN_EMAILS')
+			 ON CONFLICT (email) DO NOTHING`,
+			email,
+		)
+		if err != nil {
+			return false, fmt.Errorf("failed to seed owner %s: %w", email, err)
+		}
+		seeded = true
+	}
+
+	if err := tx.Commit(); err != nil {
+		return false, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return seeded, nil
+}
+
+// CreateAdmin adds a new admin with the given role
+func (db *DB) CreateAdmin(email, role, invitedBy string) (*Admin, error) {
+	var id int64
+	err := db.QueryRow(
+		`INSERT INTO admins (email, role, invited_by) VALUES ($1, $2, $3) RETURNING id`,
+		normalizeEmail(email), role, invitedBy,
+	).Scan(&id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create admin: %w", err)
+	}
+
+	return db.GetAdminByID(id)
+}
+
+// GetAdminByID retrieves an admin by ID
+func (db *DB) GetAdminByID(id int64) (*Admin, error) {
+	admin := &Admin{}
+	err := db.QueryRow(
+		`SELECT id, email, name, role, invited_by, last_login_at, created_at
+		 FROM admins WHERE id = $1`,
+		id,
+	).Scan(&admin.ID, &admin.Email, &admin.Name, &admin.Role, &admin.InvitedBy, &admin.LastLoginAt, &admin.CreatedAt)
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to get admin: %w", err)
+	}
+
+	return admin, nil
+}
+
+// GetAdminByEmail retrieves an admin by email
+// Returns nil without an error if the email is not an admin
+func (db *DB) GetAdminByEmail(email string) (*Admin, error) {
+	admin := &Admin{}
+	err := db.QueryRow(
+		`SELECT id, email, name, role, invited_by, last_login_at, created_at
+		 FROM admins WHERE email = $1`,
+		normalizeEmail(email),
+	).Scan(&admin.ID, &admin.Email, &admin.Name, &admin.Role, &admin.InvitedBy, &admin.LastLoginAt, &admin.CreatedAt)
+
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get admin: %w", err)
+	}
+
+	return admin, nil
+}
+
+// GetAllAdmins retrieves all admins ordered by email
+func (db *DB) GetAllAdmins() ([]*Admin, error) {
+	rows, err := db.Query(
+		`SELECT id, email, name, role, invited_by, last_login_at, created_at
+		 FROM admins ORDER BY email`,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get admins: %w", err)
+	}
+	defer rows.Close()
+
+	var admins []*Admin
+	for rows.Next() {
+		admin := &Admin{}
+		err := rows.Scan(&admin.ID, &admin.Email, &admin.Name, &admin.Role, &admin.InvitedBy, &admin.LastLoginAt, &admin.CreatedAt)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan admin: %w", err)
+		}
+		admins = append(admins, admin)
+	}
+
+	return admins, nil
+}
+
+// RecordAdminLogin stores the display name and login time of an admin
+func (db *DB) RecordAdminLogin(email, name string) error {
+	_, err := db.Exec(
+		`UPDATE admins SET name = $1, last_login_at = $2 WHERE email = $3`,
+		name, time.Now(), normalizeEmail(email),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to record admin login: %w", err)
+	}
+	return nil
+}
+
+// UpdateAdminRole changes the role of an admin
+// Returns ErrLastOwner if the admin is the only owner and would be demoted
+func (db *DB) UpdateAdminRole(id int64, role string) error {
+	tx, err := db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	if role != "owner" {
+		if err := checkNotLastOwner(tx, id); err != nil {
+			return err
+		}
+	}
+
+	_, err = tx.Exec(`UPDATE admins SET role = $1 WHERE id = $2`, role, id)
+	if err != nil {
+		return fmt.Errorf("failed to update admin role: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// DeleteAdmin removes an admin
+// Returns ErrLastOwner if the admin is the only owner
+func (db *DB) DeleteAdmin(id int64) error {
+	tx, err := db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	if err := checkNotLastOwner(tx, id); err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(`DELETE FROM admins WHERE id = $1`, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete admin: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// checkNotLastOwner returns ErrLastOwner if the admin is the only remaining owner
+// Locks the owner rows so concurrent changes can't remove all owners
+func checkNotLastOwner(tx *sql.Tx, id int64) error {
+	rows, err := tx.Query(`SELECT id FROM admins WHERE role = 'owner' FOR UPDATE`)
+	if err != nil {
+		return fmt.Errorf("failed to check owners: %w", err)
+	}
+	defer rows.Close()
+
+	var owners []int64
+	for rows.Next() {
+		var ownerID int64
+		if err := rows.Scan(&ownerID); err != nil {
+			return fmt.Errorf("failed to scan owner: %w", err)
+		}
+		owners = append(owners, ownerID)
+	}
+
+	if len(owners) == 1 && owners[0] == id {
+		return ErrLastOwner
+	}
+	return nil
+}
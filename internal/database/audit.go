@@ -0,0 +1,154 @@
+package database
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Audit actions recorded by the admin handlers
+const (
+	AuditInvitationCreate      = "invitation.create"
+	AuditInvitationUpdate      = "invitation.update"
+	AuditInvitationDelete      = "invitation.delete"
+	AuditInvitationArchive     = "invitation.archive"
+	AuditInvitationRestore     = "invitation.restore"
+	AuditInvitationMarkSent    = "invitation.mark_sent"
+	AuditInvitationNewLink     = "invitation.new_link"
+	AuditInvitationRevoke      = "invitation.revoke"
+	AuditInvitationTag         = "invitation.tag"
+	AuditInvitationCheckIn     = "invitation.check_in"
+	AuditInvitationUndoCheckIn = "invitation.undo_check_in"
+	AuditExportCSV             = "export.csv"
+	AuditExportAuditCSV        = "export.audit_csv"
+	AuditExportQRCodes         = "export.qr_zip"
+	AuditExportCards           = "export.cards"
+	AuditLanguageChange        = "admin.language"
+	AuditAdminCreate           = "admin.create"
+	AuditAdminRoleChange       = "admin.role"
+	AuditAdminDelete           = "admin.delete"
+	AuditSessionRevoke         = "admin.session_revoke"
+)
+
+// AuditSystemActor is the actor recorded for actions the server takes on its own
+const AuditSystemActor = "system"
+
+// AuditActions lists all audit actions, in the order they are offered as filters
+var AuditActions = []string{
+	AuditInvitationCreate,
+	AuditInvitationUpdate,
+	AuditInvitationArchive,
+	AuditInvitationRestore,
+	AuditInvitationDelete,
+	AuditInvitationMarkSent,
+	AuditInvitationNewLink,
+	AuditInvitationRevoke,
+	AuditInvitationTag,
+	AuditInvitationCheckIn,
+	AuditInvitationUndoCheckIn,
+	AuditExportCSV,
+	AuditExportAuditCSV,
+	AuditExportQRCodes,
+	AuditExportCards,
+	AuditLanguageChange,
+	AuditAdminCreate,
+	AuditAdminRoleChange,
+	AuditAdminDelete,
+	AuditSessionRevoke,
+}
+
+// AuditEntry is a single record of an admin action
+// BeforeValue and AfterValue hold JSON snapshots of the target
+type AuditEntry struct {
+	ID           int64
+	ActorEmail   string
+	Action       string
+	InvitationID sql.NullInt64
+	TargetName   sql.NullString
+	BeforeValue  sql.NullString
+	AfterValue   sql.NullString
+	IP           sql.NullString
+	CreatedAt    time.Time
+}
+
+// AuditFilter narrows down the audit entries returned by GetAuditEntries
+// Zero values mean "no filter"
+type AuditFilter struct {
+	ActorEmail   string
+	Action       string
+	InvitationID int64
+	From         time.Time
+	To           time.Time
+	Limit        int
+}
+
+// CreateAuditEntry records an admin action
+func (db *DB) CreateAuditEntry(entry *AuditEntry) error {
+	_, err := db.Exec(
+		`INSERT INTO audit_log (actor_email, action, invitation_id, target_name, before_value, after_value, ip, created_at)
+		 VALUES ($1, $2, $3, $4, $5, $6, $7, $8)`,
+		entry.ActorEmail, entry.Action, entry.InvitationID, entry.TargetName,
+		entry.BeforeValue, entry.AfterValue, entry.IP, time.Now(),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create audit entry: %w", err)
+	}
+	return nil
+}
+
+// GetAuditEntries retrieves audit entries matching the filter, newest first
+func (db *DB) GetAuditEntries(filter AuditFilter) ([]*AuditEntry, error) {
+	var conditions []string
+	var args []interface{}
+	addCondition := func(condition string, arg interface{}) {
+		args = append(args, arg)
+		conditions = append(conditions, fmt.Sprintf(condition, len(args)))
+	}
+
+	if filter.ActorEmail != "" {
+		addCondition("actor_email ILIKE '%%' || $%d || '%%'", filter.ActorEmail)
+	}
+	if filter.Action != "" {
+		addCondition("action = $%d", filter.Action)
+	}
+	if filter.InvitationID > 0 {
+		addCondition("invitation_id = $%d", filter.InvitationID)
+	}
+	if !filter.From.IsZero() {
+		addCondition("created_at >= $%d", filter.From)
+	}
+	if !filter.To.IsZero() {
+		addCondition("created_at < $%d", filter.To)
+	}
+
+	query := `SELECT id, actor_email, action, invitation_id, target_name, before_value, after_value, ip, created_at
+		 FROM audit_log`
+	if len(conditions) > 0 {
+		query += " WHERE " + strings.Join(conditions, " AND ")
+	}
+	query += " ORDER BY created_at DESC, id DESC"
+	if filter.Limit > 0 {
+		args = append(args, filter.Limit)
+		query += fmt.Sprintf(" LIMIT $%d", len(args))
+	}
+
+	rows, err := db.Query(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get audit entries: %w", err)
+	}
+	defer rows.Close()
+
+	var entries []*AuditEntry
+	for rows.Next() {
+		entry := &AuditEntry{}
+		err := rows.Scan(&entry.ID, &entry.ActorEmail, &entry.Action, &entry.InvitationID, &entry.TargetName,
+			&entry.BeforeValue, &entry.AfterValue, &entry.IP, &entry.CreatedAt)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan audit entry: %w", err)
+		}
+		entries = append(entries, entry)
+	}
+
+	return entries, nil
+}
@@ -0,0 +1,125 @@
+package database
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// Reasons a bulk action skips an invitation
+const (
+	BulkSkipNotFound    = "not_found"
+	BulkSkipArchived    = "archived"
+	BulkSkipAlreadySent = "already_sent"
+	BulkSkipRevoked     = "revoked"
+)
+
+// BulkFailure is an invitation a bulk action was not applied to
+type BulkFailure struct {
+	InvitationID int64
+	GuestName    string
+	Reason       string
+}
+
+// BulkResult summarizes a bulk action
+// Succeeded holds the changed invitations as they were before the action
+type BulkResult struct {
+	Succeeded []*Invitation
+	Failed    []BulkFailure
+}
+
+// skip records an invitation the bulk action was not applied to
+func (r *BulkResult) skip(id int64, guestName, reason string) {
+	r.Failed = append(r.Failed, BulkFailure{InvitationID: id, GuestName: guestName, Reason: reason})
+}
+
+// applyBulk runs a bulk action in a single transaction
+// Each invitation is locked and passed to check, which returns a skip reason or "" to apply the update;
+// a database error rolls back the whole action
+func (db *DB) applyBulk(ids []int64, check func(inv *Invitation) string, apply func(tx *sql.Tx, inv *Invitation) error) (*BulkResult, error) {
+	tx, err := db.Begin()
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	result := &BulkResult{}
+	seen := make(map[int64]bool, len(ids))
+	for _, id := range ids {
+		if seen[id] {
+			continue
+		}
+		seen[id] = true
+
+		inv := &Invitation{}
+		err := tx.QueryRow(
+			`SELECT id, guest_name, phone, token, COALESCE(code, ''), invite_message, sent_at, opened_at, responded_at, revoked_at, previewed_at, deleted_at, created_at
+			 FROM invitations WHERE id = $1 FOR UPDATE`,
+			id,
+		).Scan(&inv.ID, &inv.GuestName, &inv.Phone, &inv.Token, &inv.Code, &inv.InviteMessage,
+			&inv.SentAt, &inv.OpenedAt, &inv.RespondedAt, &inv.RevokedAt, &inv.PreviewedAt, &inv.DeletedAt, &inv.CreatedAt)
+		if errors.Is(err, sql.ErrNoRows) {
+			result.skip(id, "", BulkSkipNotFound)
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to get invitation: %w", err)
+		}
+
+		if reason := check(inv); reason != "" {
+			result.skip(id, inv.GuestName, reason)
+			continue
+		}
+		if err := apply(tx, inv); err != nil {
+			return nil, err
+		}
+		result.Succeeded = append(result.Succeeded, inv)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return result, nil
+}
+
+// BulkMarkAsSent marks the given invitations as sent, skipping archived ones and those already sent
+func (db *DB) BulkMarkAsSent(ids []int64) (*BulkResult, error) {
+	now := time.Now()
+	return db.applyBulk(ids,
+		func(inv *Invitation) string {
+			switch {
+			case inv.DeletedAt.Valid:
+				return BulkSkipArchived
+			case inv.SentAt.Valid:
+				return BulkSkipAlreadySent
+			}
+			return ""
+		},
+		func(tx *sql.Tx, inv *Invitation) error {
+			if _, err := tx.Exec(`UPDATE invitations SET sent_at = $1 WHERE id = $2`, now, inv.ID); err != nil {
+				return fmt.Errorf("failed to mark invitation as sent: %w", err)
+			}
+			return nil
+		},
+	)
+}
+
+// BulkArchive archives the given invitations, skipping those already archived
+func (db *DB) BulkArchive(ids []int64) (*BulkResult, error) {
+	now := time.Now()
+	return db.applyBulk(ids,
+		func(inv *Invitation) string {
+			if inv.DeletedAt.Valid {
+				return BulkSkipArchived
+			}
+			return ""
+		},
+		func(tx *sql.Tx, inv *Invitation) error {
+			if _, err := tx.Exec(`UPDATE invitations SET deleted_at = $1 WHERE id = $2`, now, inv.ID); err != nil {
+				return fmt.Errorf("failed to archive invitation: %w", err)
+			}
+			return nil
+		},
+	)
+}
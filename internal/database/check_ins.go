@@ -0,0 +1,93 @@
+package database
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+)
+
+// CheckInGuest is an invitation whose guests said they are coming, as listed at the entrance
+// CheckedInAt is set once the party has arrived
+type CheckInGuest struct {
+	InvitationID int64
+	GuestName    string
+	PlusOneName  sql.NullString
+	PlusOne      bool
+	KidsCount    int
+	CheckedInAt  sql.NullTime
+	CheckedInBy  sql.NullString
+}
+
+// PartySize returns how many people are expected with the invitation
+func (g *CheckInGuest) PartySize() int {
+	size := 1 + g.KidsCount
+	if g.PlusOne {
+		size++
+	}
+	return size
+}
+
+// GetCheckInGuests retrieves the guests attending the event by name, optionally matching
+// a search on the guest's or companion's name
+func (db *DB) GetCheckInGuests(search string) ([]*CheckInGuest, error) {
+	query := `SELECT i.id, i.guest_name, r.plus_one_name, r.plus_one, r.kids_count, c.checked_in_at, c.checked_in_by
+		 FROM invitations i
+		 JOIN responses r ON r.invitation_id = i.id AND r.is_latest = TRUE AND r.attending = TRUE
+		 LEFT JOIN check_ins c ON c.invitation_id = i.id
+		 WHERE i.deleted_at IS NULL`
+	var args []interface{}
+	if search != "" {
+		args = append(args, escapeLike(search))
+		query += ` AND (i.guest_name ILIKE '%' || $1 || '%' OR r.plus_one_name ILIKE '%' || $1 || '%')`
+	}
+	query += ` ORDER BY i.guest_name`
+
+	rows, err := db.Query(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get check-in guests: %w", err)
+	}
+	defer rows.Close()
+
+	var guests []*CheckInGuest
+	for rows.Next() {
+		guest := &CheckInGuest{}
+		if err := rows.Scan(&guest.InvitationID, &guest.GuestName, &guest.PlusOneName, &guest.PlusOne,
+			&guest.KidsCount, &guest.CheckedInAt, &guest.CheckedInBy); err != nil {
+			return nil, fmt.Errorf("failed to scan check-in guest: %w", err)
+		}
+		guests = append(guests, guest)
+	}
+	return guests, rows.Err()
+}
+
+// CheckIn marks an invitation's guests as arrived
+// Returns false if they were already checked in
+func (db *DB) CheckIn(invitationID int64, by string) (bool, error) {
+	result, err := db.Exec(
+		`INSERT INTO check_ins (invitation_id, checked_in_by, checked_in_at) VALUES ($1, $2, $3)
+		 ON CONFLICT (invitation_id) DO NOTHING`,
+		invitationID, by, time.Now(),
+	)
+	if err != nil {
+		return false, fmt.Errorf("failed to check in: %w", err)
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to check in: %w", err)
+	}
+	return affected > 0, nil
+}
+
+// UndoCheckIn clears an invitation's check-in, e.g. after checking in the wrong guest
+// Returns false if they were not checked in
+func (db *DB) UndoCheckIn(invitationID int64) (bool, error) {
+	result, err := db.Exec(`DELETE FROM check_ins WHERE invitation_id = $1`, invitationID)
+	if err != nil {
+		return false, fmt.Errorf("failed to undo check-in: %w", err)
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to undo check-in: %w", err)
+	}
+	return affected > 0, nil
+}
@@ -0,0 +1,58 @@
+// Package dbtest opens the Postgres database used by tests that need real queries
+package dbtest
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/AlexTLDR/evite/internal/database"
+	"github.com/pressly/goose/v3"
+)
+
+// Open connects to the database in TEST_DATABASE_URL and migrates it
+// The test is skipped when TEST_DATABASE_URL is not set; use a throwaway database,
+// since tests write to it (e.g. the docker-compose one with a separate database name)
+func Open(t *testing.T) *database.DB {
+	t.Helper()
+
+	url := os.Getenv("TEST_DATABASE_URL")
+	if url == "" {
+		t.Skip("TEST_DATABASE_URL is not set")
+	}
+
+	db, err := database.New(url)
+	if err != nil {
+		t.Fatalf("Failed to open test database: %v", err)
+	}
+	t.Cleanup(func() { _ = db.Close() })
+
+	// Migrations live at the repository root, wherever the test runs from
+	_, file, _, _ := runtime.Caller(0)
+	migrations := filepath.Join(filepath.Dir(file), "..", "..", "..", "migrations")
+	if err := goose.SetDialect("postgres"); err != nil {
+		t.Fatalf("Failed to set goose dialect: %v", err)
+	}
+	if err := goose.Up(db.DB, migrations); err != nil {
+		t.Fatalf("Failed to migrate test database: %v", err)
+	}
+
+	return db
+}
+
+// CreateInvitation creates an invitation with a unique phone number and deletes it when the test ends
+func CreateInvitation(t *testing.T, db *database.DB, guestName string) *database.Invitation {
+	t.Helper()
+
+	phone := "+40" + strconv.FormatInt(time.Now().UnixNano()%1_000_000_000, 10)
+	invitation, err := db.CreateInvitation(guestName, phone, "", nil)
+	if err != nil {
+		t.Fatalf("Failed to create invitation: %v", err)
+	}
+	t.Cleanup(func() { _ = db.DeleteInvitation(invitation.ID) })
+
+	return invitation
+}
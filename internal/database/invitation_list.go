@@ -0,0 +1,228 @@
+package database
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/AlexTLDR/evite/internal/utils"
+)
+
+// Status filters of the invitations list
+const (
+	InvitationStatusNotSent    = "not_sent"
+	InvitationStatusNotOpened  = "not_opened"
+	InvitationStatusNoResponse = "no_response"
+	InvitationStatusAttending  = "attending"
+	InvitationStatusDeclined   = "declined"
+	InvitationStatusVegan      = "vegan"
+	InvitationStatusKids       = "kids"
+)
+
+// InvitationStatuses lists the status filters, in the order they are offered in the list
+var InvitationStatuses = []string{
+	InvitationStatusNotSent,
+	InvitationStatusNotOpened,
+	InvitationStatusNoResponse,
+	InvitationStatusAttending,
+	InvitationStatusDeclined,
+	InvitationStatusVegan,
+	InvitationStatusKids,
+}
+
+// invitationStatusConditions maps each status filter to its SQL condition
+var invitationStatusConditions = map[string]string{
+	InvitationStatusNotSent:    "i.sent_at IS NULL",
+	InvitationStatusNotOpened:  "i.sent_at IS NOT NULL AND i.opened_at IS NULL",
+	InvitationStatusNoResponse: "i.opened_at IS NOT NULL AND i.responded_at IS NULL",
+	InvitationStatusAttending:  "r.attending",
+	InvitationStatusDeclined:   "r.id IS NOT NULL AND NOT r.attending",
+	InvitationStatusVegan:      "r.attending AND (r.menu_preference = 'vegan' OR (r.plus_one AND r.companion_menu_preference = 'vegan'))",
+	InvitationStatusKids:       "r.attending AND r.kids_count > 0",
+}
+
+// Sort orders of the invitations list
+const (
+	InvitationSortCreated   = "created"
+	InvitationSortName      = "name"
+	InvitationSortSent      = "sent"
+	InvitationSortResponded = "responded"
+)
+
+// InvitationSorts lists the sort orders, in the order they are offered in the list
+var InvitationSorts = []string{
+	InvitationSortCreated,
+	InvitationSortName,
+	InvitationSortSent,
+	InvitationSortResponded,
+}
+
+// invitationSortKeys maps each sort order to its SQL sort key and the type the key is compared as
+// Missing dates sort as the oldest so that keyset pagination never compares NULLs
+var invitationSortKeys = map[string]struct{ expr, cast string }{
+	InvitationSortCreated:   {"i.created_at", "timestamp"},
+	InvitationSortName:      {"LOWER(i.guest_name)", "text"},
+	InvitationSortSent:      {"COALESCE(i.sent_at, 'epoch')", "timestamp"},
+	InvitationSortResponded: {"COALESCE(i.responded_at, 'epoch')", "timestamp"},
+}
+
+// IsInvitationSort reports whether sort is a known sort order of the invitations list
+func IsInvitationSort(sort string) bool {
+	_, ok := invitationSortKeys[sort]
+	return ok
+}
+
+// InvitationCursor marks the last invitation of a page: its sort key and ID
+type InvitationCursor struct {
+	ID  int64
+	Key string
+}
+
+// String encodes the cursor for use in URLs
+func (c InvitationCursor) String() string {
+	return fmt.Sprintf("%d:%s", c.ID, c.Key)
+}
+
+// ParseInvitationCursor decodes a cursor produced by InvitationCursor.String
+func ParseInvitationCursor(value string) (*InvitationCursor, error) {
+	idStr, key, ok := strings.Cut(value, ":")
+	if !ok {
+		return nil, fmt.Errorf("invalid cursor %q", value)
+	}
+	id, err := strconv.ParseInt(idStr, 10, 64)
+	if err != nil || id <= 0 {
+		return nil, fmt.Errorf("invalid cursor %q", value)
+	}
+	return &InvitationCursor{ID: id, Key: key}, nil
+}
+
+// validCursorKey reports whether a cursor key can be compared as the given SQL type
+func validCursorKey(key, cast string) bool {
+	if cast != "timestamp" {
+		return true
+	}
+	// Fractional seconds are accepted even though the layout leaves them out
+	_, err := time.Parse("2006-01-02 15:04:05", key)
+	return err == nil
+}
+
+// likeEscaper escapes the LIKE wildcards so that they match literally
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+// escapeLike escapes a user-supplied value for use in a LIKE or ILIKE pattern
+func escapeLike(value string) string {
+	return likeEscaper.Replace(value)
+}
+
+// InvitationFilter narrows down and orders the invitations returned by GetInvitationsPage
+// Zero values mean "no filter"; the default order is newest first
+type InvitationFilter struct {
+	Search string
+	Status string
+	Tag    string
+	Sort   string
+	Asc    bool
+	After  *InvitationCursor
+	Limit  int
+}
+
+// InvitationPage is a page of the invitations list
+// Next is set when there are more invitations after this page
+type InvitationPage struct {
+	Invitations []*InvitationWithResponse
+	Total       int
+	Next        *InvitationCursor
+}
+
+// GetInvitationsPage retrieves a page of invitations with their latest responses matching the filter
+// Pages are keyset-paginated on the sort key and ID, so they stay stable while invitations are added
+func (db *DB) GetInvitationsPage(filter InvitationFilter) (*InvitationPage, error) {
+	conditions := []string{"i.deleted_at IS NULL"}
+	var args []interface{}
+	addCondition := func(condition string, arg interface{}) {
+		args = append(args, arg)
+		conditions = append(conditions, fmt.Sprintf(condition, len(args)))
+	}
+
+	if filter.Search != "" {
+		// Phone numbers are stored in E.164, so a full local number such as "0721 234 567" is normalized first
+		phone := filter.Search
+		if normalized, err := utils.NormalizePhoneNumber(filter.Search); err == nil {
+			phone = normalized
+		}
+		args = append(args, escapeLike(filter.Search), escapeLike(phone))
+		conditions = append(conditions, fmt.Sprintf(
+			"(i.guest_name ILIKE '%%' || $%[1]d || '%%' OR i.phone ILIKE '%%' || $%[2]d || '%%' OR r.guest_name_tag ILIKE '%%' || $%[1]d || '%%')",
+			len(args)-1, len(args)))
+	}
+	if condition, ok := invitationStatusConditions[filter.Status]; ok {
+		conditions = append(conditions, condition)
+	}
+	if filter.Tag != "" {
+		addCondition(`EXISTS (
+			SELECT 1 FROM invitation_tags it JOIN tags t ON t.id = it.tag_id
+			WHERE it.invitation_id = i.id AND LOWER(t.name) = LOWER($%d)
+		 )`, filter.Tag)
+	}
+
+	from := `FROM invitations i
+		 LEFT JOIN responses r ON i.id = r.invitation_id AND r.is_latest = TRUE
+		 WHERE ` + strings.Join(conditions, " AND ")
+
+	page := &InvitationPage{}
+	if err := db.QueryRow(`SELECT COUNT(*) `+from, args...).Scan(&page.Total); err != nil {
+		return nil, fmt.Errorf("failed to count invitations: %w", err)
+	}
+
+	sortKey, ok := invitationSortKeys[filter.Sort]
+	if !ok {
+		sortKey = invitationSortKeys[InvitationSortCreated]
+	}
+	// A cursor from another sort order (or edited by hand) starts over from the first page
+	if filter.After != nil && !validCursorKey(filter.After.Key, sortKey.cast) {
+		filter.After = nil
+	}
+	direction, comparison := "DESC", "<"
+	if filter.Asc {
+		direction, comparison = "ASC", ">"
+	}
+
+	if filter.After != nil {
+		args = append(args, filter.After.Key, filter.After.ID)
+		from += fmt.Sprintf(" AND (%s, i.id) %s ($%d::%s, $%d)", sortKey.expr, comparison, len(args)-1, sortKey.cast, len(args))
+	}
+
+	query := `SELECT ` + invitationWithResponseColumns + `, (` + sortKey.expr + `)::text
+		 ` + from + fmt.Sprintf(" ORDER BY %s %s, i.id %s", sortKey.expr, direction, direction)
+	if filter.Limit > 0 {
+		// Fetch one more row to know whether there is a next page
+		args = append(args, filter.Limit+1)
+		query += fmt.Sprintf(" LIMIT $%d", len(args))
+	}
+
+	rows, err := db.Query(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get invitations: %w", err)
+	}
+	defer rows.Close()
+
+	var keys []string
+	for rows.Next() {
+		var key string
+		iwr, err := scanInvitationWithResponse(rows, &key)
+		if err != nil {
+			return nil, err
+		}
+		page.Invitations = append(page.Invitations, iwr)
+		keys = append(keys, key)
+	}
+
+	if filter.Limit > 0 && len(page.Invitations) > filter.Limit {
+		page.Invitations = page.Invitations[:filter.Limit]
+		last := page.Invitations[filter.Limit-1]
+		page.Next = &InvitationCursor{ID: last.ID, Key: keys[filter.Limit-1]}
+	}
+
+	return page, nil
+}
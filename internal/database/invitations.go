@@ -6,6 +6,8 @@ import (
 	"encoding/hex"
 	"fmt"
 	"time"
+
+	"github.com/AlexTLDR/evite/internal/utils"
 )
 
 func GenerateToken() (string, error) {
@@ -17,45 +19,67 @@ func GenerateToken() (string, error) {
 	return hex.EncodeToString(b), nil
 }
 
-// CreateInvitation creates a new invitation with a unique token
-func (db *DB) CreateInvitation(guestName, phone, inviteMessage string) (*Invitation, error) {
-	// Generate a unique token with retry logic
-	var token string
-	var err error
+// generateUnique generates a value that is not used in the given invitations column yet
+func (db *DB) generateUnique(column string, generate func() (string, error)) (string, error) {
 	maxRetries := 5
 
 	for i := 0; i < maxRetries; i++ {
-		token, err = GenerateToken()
+		value, err := generate()
 		if err != nil {
-			return nil, err
+			return "", err
 		}
 
-		// Check if a token already exists
+		// Check if the value already exists
 		var exists bool
-		err = db.QueryRow("SELECT EXISTS(SELECT 1 FROM invitations WHERE token = $1)", token).Scan(&exists)
+		err = db.QueryRow(fmt.Sprintf("SELECT EXISTS(SELECT 1 FROM invitations WHERE %s = $1)", column), value).Scan(&exists)
 		if err != nil {
-			return nil, fmt.Errorf("failed to check token uniqueness: %w", err)
+			return "", fmt.Errorf("failed to check %s uniqueness: %w", column, err)
 		}
 
 		if !exists {
-			break
+			return value, nil
 		}
+	}
 
-		if i == maxRetries-1 {
-			return nil, fmt.Errorf("failed to generate unique token after %d retries", maxRetries)
-		}
+	return "", fmt.Errorf("failed to generate unique %s after %d retries", column, maxRetries)
+}
+
+// CreateInvitation creates a new invitation with a unique token and the given tags
+// The invitation and its tags are saved together, so a failure leaves neither behind
+func (db *DB) CreateInvitation(guestName, phone, inviteMessage string, tags []string) (*Invitation, error) {
+	token, err := db.generateUnique("token", GenerateToken)
+	if err != nil {
+		return nil, err
+	}
+	code, err := db.generateUnique("code", utils.GenerateInviteCode)
+	if err != nil {
+		return nil, err
 	}
 
+	tx, err := db.Begin()
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
 	var id int64
-	err = db.QueryRow(
-		`INSERT INTO invitations (guest_name, phone, token, invite_message)
-		 VALUES ($1, $2, $3, $4) RETURNING id`,
-		guestName, phone, token, inviteMessage,
+	err = tx.QueryRow(
+		`INSERT INTO invitations (guest_name, phone, token, code, invite_message)
+		 VALUES ($1, $2, $3, $4, $5) RETURNING id`,
+		guestName, phone, token, code, inviteMessage,
 	).Scan(&id)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create invitation: %w", err)
 	}
 
+	if err := setInvitationTags(tx, id, tags); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
 	return db.GetInvitationByID(id)
 }
 
@@ -63,11 +87,11 @@ func (db *DB) CreateInvitation(guestName, phone, inviteMessage string) (*Invitat
 func (db *DB) GetInvitationByID(id int64) (*Invitation, error) {
 	inv := &Invitation{}
 	err := db.QueryRow(
-		`SELECT id, guest_name, phone, token, invite_message, sent_at, opened_at, responded_at, created_at
+		`SELECT id, guest_name, phone, token, COALESCE(code, ''), invite_message, sent_at, opened_at, responded_at, revoked_at, previewed_at, deleted_at, created_at
 		 FROM invitations WHERE id = $1`,
 		id,
-	).Scan(&inv.ID, &inv.GuestName, &inv.Phone, &inv.Token, &inv.InviteMessage,
-		&inv.SentAt, &inv.OpenedAt, &inv.RespondedAt, &inv.CreatedAt)
+	).Scan(&inv.ID, &inv.GuestName, &inv.Phone, &inv.Token, &inv.Code, &inv.InviteMessage,
+		&inv.SentAt, &inv.OpenedAt, &inv.RespondedAt, &inv.RevokedAt, &inv.PreviewedAt, &inv.DeletedAt, &inv.CreatedAt)
 
 	if err != nil {
 		return nil, fmt.Errorf("failed to get invitation: %w", err)
@@ -80,11 +104,28 @@ func (db *DB) GetInvitationByID(id int64) (*Invitation, error) {
 func (db *DB) GetInvitationByToken(token string) (*Invitation, error) {
 	inv := &Invitation{}
 	err := db.QueryRow(
-		`SELECT id, guest_name, phone, token, invite_message, sent_at, opened_at, responded_at, created_at
-		 FROM invitations WHERE token = $1`,
+		`SELECT id, guest_name, phone, token, COALESCE(code, ''), invite_message, sent_at, opened_at, responded_at, revoked_at, previewed_at, deleted_at, created_at
+		 FROM invitations WHERE token = $1 AND deleted_at IS NULL`,
 		token,
-	).Scan(&inv.ID, &inv.GuestName, &inv.Phone, &inv.Token, &inv.InviteMessage,
-		&inv.SentAt, &inv.OpenedAt, &inv.RespondedAt, &inv.CreatedAt)
+	).Scan(&inv.ID, &inv.GuestName, &inv.Phone, &inv.Token, &inv.Code, &inv.InviteMessage,
+		&inv.SentAt, &inv.OpenedAt, &inv.RespondedAt, &inv.RevokedAt, &inv.PreviewedAt, &inv.DeletedAt, &inv.CreatedAt)
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to get invitation: %w", err)
+	}
+
+	return inv, nil
+}
+
+// GetInvitationByCode retrieves an invitation by its short code
+func (db *DB) GetInvitationByCode(code string) (*Invitation, error) {
+	inv := &Invitation{}
+	err := db.QueryRow(
+		`SELECT id, guest_name, phone, token, COALESCE(code, ''), invite_message, sent_at, opened_at, responded_at, revoked_at, previewed_at, deleted_at, created_at
+		 FROM invitations WHERE code = $1 AND deleted_at IS NULL`,
+		code,
+	).Scan(&inv.ID, &inv.GuestName, &inv.Phone, &inv.Token, &inv.Code, &inv.InviteMessage,
+		&inv.SentAt, &inv.OpenedAt, &inv.RespondedAt, &inv.RevokedAt, &inv.PreviewedAt, &inv.DeletedAt, &inv.CreatedAt)
 
 	if err != nil {
 		return nil, fmt.Errorf("failed to get invitation: %w", err)
@@ -93,15 +134,47 @@ func (db *DB) GetInvitationByToken(token string) (*Invitation, error) {
 	return inv, nil
 }
 
+// EnsureInvitationCodes gives a short code to invitations created before codes existed
+func (db *DB) EnsureInvitationCodes() error {
+	rows, err := db.Query(`SELECT id FROM invitations WHERE code IS NULL`)
+	if err != nil {
+		return fmt.Errorf("failed to get invitations without code: %w", err)
+	}
+	var ids []int64
+	for rows.Next() {
+		var id int64
+		if err := rows.Scan(&id); err != nil {
+			_ = rows.Close()
+			return fmt.Errorf("failed to scan invitation id: %w", err)
+		}
+		ids = append(ids, id)
+	}
+	if err := rows.Close(); err != nil {
+		return fmt.Errorf("failed to close rows: %w", err)
+	}
+
+	for _, id := range ids {
+		code, err := db.generateUnique("code", utils.GenerateInviteCode)
+		if err != nil {
+			return err
+		}
+		if _, err := db.Exec(`UPDATE invitations SET code = $1 WHERE id = $2`, code, id); err != nil {
+			return fmt.Errorf("failed to set invitation code: %w", err)
+		}
+	}
+
+	return nil
+}
+
 // GetInvitationByPhone retrieves an invitation by phone number
 func (db *DB) GetInvitationByPhone(phone string) (*Invitation, error) {
 	inv := &Invitation{}
 	err := db.QueryRow(
-		`SELECT id, guest_name, phone, token, invite_message, sent_at, opened_at, responded_at, created_at
-		 FROM invitations WHERE phone = $1`,
+		`SELECT id, guest_name, phone, token, COALESCE(code, ''), invite_message, sent_at, opened_at, responded_at, revoked_at, previewed_at, deleted_at, created_at
+		 FROM invitations WHERE phone = $1 AND deleted_at IS NULL`,
 		phone,
-	).Scan(&inv.ID, &inv.GuestName, &inv.Phone, &inv.Token, &inv.InviteMessage,
-		&inv.SentAt, &inv.OpenedAt, &inv.RespondedAt, &inv.CreatedAt)
+	).Scan(&inv.ID, &inv.GuestName, &inv.Phone, &inv.Token, &inv.Code, &inv.InviteMessage,
+		&inv.SentAt, &inv.OpenedAt, &inv.RespondedAt, &inv.RevokedAt, &inv.PreviewedAt, &inv.DeletedAt, &inv.CreatedAt)
 
 	if err != nil {
 		return nil, fmt.Errorf("failed to get invitation: %w", err)
@@ -110,12 +183,34 @@ func (db *DB) GetInvitationByPhone(phone string) (*Invitation, error) {
 	return inv, nil
 }
 
-// GetAllInvitations retrieves all invitations
+// GetAllInvitations retrieves all invitations except archived ones
 func (db *DB) GetAllInvitations() ([]*Invitation, error) {
-	rows, err := db.Query(
-		`SELECT id, guest_name, phone, token, invite_message, sent_at, opened_at, responded_at, created_at
-		 FROM invitations ORDER BY created_at DESC`,
+	return db.queryInvitations(
+		`SELECT id, guest_name, phone, token, COALESCE(code, ''), invite_message, sent_at, opened_at, responded_at, revoked_at, previewed_at, deleted_at, created_at
+		 FROM invitations WHERE deleted_at IS NULL ORDER BY created_at DESC`,
 	)
+}
+
+// GetArchivedInvitations retrieves the archived (soft-deleted) invitations, most recently archived first
+func (db *DB) GetArchivedInvitations() ([]*Invitation, error) {
+	return db.queryInvitations(
+		`SELECT id, guest_name, phone, token, COALESCE(code, ''), invite_message, sent_at, opened_at, responded_at, revoked_at, previewed_at, deleted_at, created_at
+		 FROM invitations WHERE deleted_at IS NOT NULL ORDER BY deleted_at DESC`,
+	)
+}
+
+// GetInvitationsArchivedBefore retrieves the invitations archived before a time, which are due to be purged
+func (db *DB) GetInvitationsArchivedBefore(before time.Time) ([]*Invitation, error) {
+	return db.queryInvitations(
+		`SELECT id, guest_name, phone, token, COALESCE(code, ''), invite_message, sent_at, opened_at, responded_at, revoked_at, previewed_at, deleted_at, created_at
+		 FROM invitations WHERE deleted_at < $1 ORDER BY deleted_at`,
+		before,
+	)
+}
+
+// queryInvitations runs a query selecting full invitation rows
+func (db *DB) queryInvitations(query string, args ...interface{}) ([]*Invitation, error) {
+	rows, err := db.Query(query, args...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get invitations: %w", err)
 	}
@@ -129,8 +224,8 @@ func (db *DB) GetAllInvitations() ([]*Invitation, error) {
 	var invitations []*Invitation
 	for rows.Next() {
 		inv := &Invitation{}
-		err := rows.Scan(&inv.ID, &inv.GuestName, &inv.Phone, &inv.Token, &inv.InviteMessage,
-			&inv.SentAt, &inv.OpenedAt, &inv.RespondedAt, &inv.CreatedAt)
+		err := rows.Scan(&inv.ID, &inv.GuestName, &inv.Phone, &inv.Token, &inv.Code, &inv.InviteMessage,
+			&inv.SentAt, &inv.OpenedAt, &inv.RespondedAt, &inv.RevokedAt, &inv.PreviewedAt, &inv.DeletedAt, &inv.CreatedAt)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan invitation: %w", err)
 		}
@@ -164,19 +259,110 @@ func (db *DB) MarkAsOpened(id int64) error {
 	return nil
 }
 
-// UpdateInvitation updates an invitation's guest name and phone
-func (db *DB) UpdateInvitation(id int64, guestName, phone string) error {
+// MarkAsPreviewed records that a link preview crawler or prefetcher fetched the invitation link
+// Kept apart from opened_at, which only tracks visits by people
+func (db *DB) MarkAsPreviewed(id int64) error {
 	_, err := db.Exec(
+		`UPDATE invitations SET previewed_at = $1 WHERE id = $2 AND previewed_at IS NULL`,
+		time.Now(), id,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to mark invitation as previewed: %w", err)
+	}
+	return nil
+}
+
+// RegenerateInvitationToken gives an invitation a new token and code, which also lifts a revocation
+// The old link and code stop working and the invite message is updated to contain the new ones
+func (db *DB) RegenerateInvitationToken(id int64) (*Invitation, error) {
+	token, err := db.generateUnique("token", GenerateToken)
+	if err != nil {
+		return nil, err
+	}
+	code, err := db.generateUnique("code", utils.GenerateInviteCode)
+	if err != nil {
+		return nil, err
+	}
+
+	// The right-hand side of SET sees the old values, so REPLACE swaps them in the message
+	result, err := db.Exec(
+		`UPDATE invitations
+		 SET token = $1, code = $2, revoked_at = NULL,
+		     invite_message = REPLACE(REPLACE(invite_message, token, $1), COALESCE(code, $2), $2)
+		 WHERE id = $3`,
+		token, code, id,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to regenerate invitation token: %w", err)
+	}
+	if n, err := result.RowsAffected(); err == nil && n == 0 {
+		return nil, fmt.Errorf("failed to regenerate invitation token: %w", sql.ErrNoRows)
+	}
+
+	return db.GetInvitationByID(id)
+}
+
+// RevokeInvitation disables an invitation's link until a new token is generated
+func (db *DB) RevokeInvitation(id int64) error {
+	_, err := db.Exec(
+		`UPDATE invitations SET revoked_at = $1 WHERE id = $2 AND revoked_at IS NULL`,
+		time.Now(), id,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to revoke invitation: %w", err)
+	}
+	return nil
+}
+
+// UpdateInvitation updates an invitation's guest name, phone and tags in one transaction
+func (db *DB) UpdateInvitation(id int64, guestName, phone string, tags []string) error {
+	tx, err := db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	_, err = tx.Exec(
 		`UPDATE invitations SET guest_name = $1, phone = $2 WHERE id = $3`,
 		guestName, phone, id,
 	)
 	if err != nil {
 		return fmt.Errorf("failed to update invitation: %w", err)
 	}
+
+	if err := setInvitationTags(tx, id, tags); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return nil
+}
+
+// ArchiveInvitation soft-deletes an invitation: it disappears from lists, stats and exports and its link stops working
+// until it is restored or purged
+func (db *DB) ArchiveInvitation(id int64) error {
+	_, err := db.Exec(
+		`UPDATE invitations SET deleted_at = $1 WHERE id = $2 AND deleted_at IS NULL`,
+		time.Now(), id,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to archive invitation: %w", err)
+	}
 	return nil
 }
 
-// DeleteInvitation deletes an invitation and all its responses
+// RestoreInvitation brings an archived invitation back
+func (db *DB) RestoreInvitation(id int64) error {
+	_, err := db.Exec(`UPDATE invitations SET deleted_at = NULL WHERE id = $1`, id)
+	if err != nil {
+		return fmt.Errorf("failed to restore invitation: %w", err)
+	}
+	return nil
+}
+
+// DeleteInvitation permanently deletes an invitation with all its responses, visits, tags and check-in
 func (db *DB) DeleteInvitation(id int64) error {
 	tx, err := db.Begin()
 	if err != nil {
@@ -190,6 +376,21 @@ func (db *DB) DeleteInvitation(id int64) error {
 		return fmt.Errorf("failed to delete responses: %w", err)
 	}
 
+	_, err = tx.Exec(`DELETE FROM invitation_visits WHERE invitation_id = $1`, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete visits: %w", err)
+	}
+
+	_, err = tx.Exec(`DELETE FROM invitation_tags WHERE invitation_id = $1`, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete tags: %w", err)
+	}
+
+	_, err = tx.Exec(`DELETE FROM check_ins WHERE invitation_id = $1`, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete check-in: %w", err)
+	}
+
 	// Delete the invitation
 	_, err = tx.Exec(`DELETE FROM invitations WHERE id = $1`, id)
 	if err != nil {
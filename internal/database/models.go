@@ -10,10 +10,14 @@ type Invitation struct {
 	GuestName     string
 	Phone         string
 	Token         string
+	Code          string
 	InviteMessage string
 	SentAt        sql.NullTime
 	OpenedAt      sql.NullTime
 	RespondedAt   sql.NullTime
+	RevokedAt     sql.NullTime
+	PreviewedAt   sql.NullTime
+	DeletedAt     sql.NullTime
 	CreatedAt     time.Time
 }
 
@@ -30,9 +34,34 @@ type Response struct {
 	Comment                 sql.NullString
 	SubmittedAt             time.Time
 	IsLatest                bool
+	WithdrawnAt             sql.NullTime
 }
 
 type InvitationWithResponse struct {
 	Invitation
 	Response *Response
 }
+
+type Admin struct {
+	ID          int64
+	Email       string
+	Name        sql.NullString
+	Role        string
+	InvitedBy   sql.NullString
+	LastLoginAt sql.NullTime
+	CreatedAt   time.Time
+}
+
+// AdminSession is a signed-in admin browser
+// ExpiresAt is the absolute timeout; the idle timeout is checked against LastSeenAt
+type AdminSession struct {
+	ID         int64
+	TokenHash  string
+	Email      string
+	Name       sql.NullString
+	UserAgent  sql.NullString
+	IP         sql.NullString
+	CreatedAt  time.Time
+	LastSeenAt time.Time
+	ExpiresAt  time.Time
+}
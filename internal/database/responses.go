@@ -2,10 +2,15 @@ package database
 
 import (
 	"database/sql"
+	"errors"
 	"fmt"
 	"time"
 )
 
+// ErrNoResponse is returned when withdrawing an invitation that has no current response,
+// e.g. because the guest already withdrew it
+var ErrNoResponse = errors.New("no response to withdraw")
+
 // CreateResponse creates a new response and marks previous responses as not latest
 func (db *DB) CreateResponse(invitationID int64, attending, plusOne bool, plusOneName, guestNameTag string, kidsCount int, menuPreference, companionMenuPreference, comment string) (*Response, error) {
 	tx, err := db.Begin()
@@ -64,15 +69,48 @@ func (db *DB) CreateResponse(invitationID int64, attending, plusOne bool, plusOn
 	return db.GetResponseByID(id)
 }
 
+// WithdrawResponse withdraws the guest's current response so the invitation counts as unanswered again
+// The response is kept for the history but no longer latest
+func (db *DB) WithdrawResponse(invitationID int64) error {
+	tx, err := db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	now := time.Now()
+	result, err := tx.Exec(
+		`UPDATE responses SET is_latest = FALSE, withdrawn_at = $1 WHERE invitation_id = $2 AND is_latest = TRUE`,
+		now, invitationID,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to withdraw response: %w", err)
+	}
+	if rows, _ := result.RowsAffected(); rows == 0 {
+		return ErrNoResponse
+	}
+
+	_, err = tx.Exec(`UPDATE invitations SET responded_at = NULL WHERE id = $1`, invitationID)
+	if err != nil {
+		return fmt.Errorf("failed to update invitation: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
 // GetResponseByID retrieves a response by ID
 func (db *DB) GetResponseByID(id int64) (*Response, error) {
 	resp := &Response{}
 	err := db.QueryRow(
-		`SELECT id, invitation_id, attending, plus_one, plus_one_name, guest_name_tag, kids_count, menu_preference, companion_menu_preference, comment, submitted_at, is_latest
+		`SELECT id, invitation_id, attending, plus_one, plus_one_name, guest_name_tag, kids_count, menu_preference, companion_menu_preference, comment, submitted_at, is_latest, withdrawn_at
 		 FROM responses WHERE id = $1`,
 		id,
 	).Scan(&resp.ID, &resp.InvitationID, &resp.Attending, &resp.PlusOne, &resp.PlusOneName,
-		&resp.GuestNameTag, &resp.KidsCount, &resp.MenuPreference, &resp.CompanionMenuPreference, &resp.Comment, &resp.SubmittedAt, &resp.IsLatest)
+		&resp.GuestNameTag, &resp.KidsCount, &resp.MenuPreference, &resp.CompanionMenuPreference, &resp.Comment, &resp.SubmittedAt, &resp.IsLatest, &resp.WithdrawnAt)
 
 	if err != nil {
 		return nil, fmt.Errorf("failed to get response: %w", err)
@@ -85,11 +123,11 @@ func (db *DB) GetResponseByID(id int64) (*Response, error) {
 func (db *DB) GetLatestResponseByInvitationID(invitationID int64) (*Response, error) {
 	resp := &Response{}
 	err := db.QueryRow(
-		`SELECT id, invitation_id, attending, plus_one, plus_one_name, guest_name_tag, kids_count, menu_preference, companion_menu_preference, comment, submitted_at, is_latest
+		`SELECT id, invitation_id, attending, plus_one, plus_one_name, guest_name_tag, kids_count, menu_preference, companion_menu_preference, comment, submitted_at, is_latest, withdrawn_at
 		 FROM responses WHERE invitation_id = $1 AND is_latest = TRUE`,
 		invitationID,
 	).Scan(&resp.ID, &resp.InvitationID, &resp.Attending, &resp.PlusOne, &resp.PlusOneName,
-		&resp.GuestNameTag, &resp.KidsCount, &resp.MenuPreference, &resp.CompanionMenuPreference, &resp.Comment, &resp.SubmittedAt, &resp.IsLatest)
+		&resp.GuestNameTag, &resp.KidsCount, &resp.MenuPreference, &resp.CompanionMenuPreference, &resp.Comment, &resp.SubmittedAt, &resp.IsLatest, &resp.WithdrawnAt)
 
 	if err == sql.ErrNoRows {
 		return nil, nil
@@ -104,7 +142,7 @@ func (db *DB) GetLatestResponseByInvitationID(invitationID int64) (*Response, er
 // GetAllResponsesByInvitationID retrieves all responses for an invitation (history)
 func (db *DB) GetAllResponsesByInvitationID(invitationID int64) ([]*Response, error) {
 	rows, err := db.Query(
-		`SELECT id, invitation_id, attending, plus_one, plus_one_name, guest_name_tag, kids_count, menu_preference, companion_menu_preference, comment, submitted_at, is_latest
+		`SELECT id, invitation_id, attending, plus_one, plus_one_name, guest_name_tag, kids_count, menu_preference, companion_menu_preference, comment, submitted_at, is_latest, withdrawn_at
 		 FROM responses WHERE invitation_id = $1 ORDER BY submitted_at DESC`,
 		invitationID,
 	)
@@ -117,7 +155,7 @@ func (db *DB) GetAllResponsesByInvitationID(invitationID int64) ([]*Response, er
 	for rows.Next() {
 		resp := &Response{}
 		err := rows.Scan(&resp.ID, &resp.InvitationID, &resp.Attending, &resp.PlusOne, &resp.PlusOneName,
-			&resp.GuestNameTag, &resp.KidsCount, &resp.MenuPreference, &resp.CompanionMenuPreference, &resp.Comment, &resp.SubmittedAt, &resp.IsLatest)
+			&resp.GuestNameTag, &resp.KidsCount, &resp.MenuPreference, &resp.CompanionMenuPreference, &resp.Comment, &resp.SubmittedAt, &resp.IsLatest, &resp.WithdrawnAt)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan response: %w", err)
 		}
@@ -127,14 +165,45 @@ func (db *DB) GetAllResponsesByInvitationID(invitationID int64) ([]*Response, er
 	return responses, nil
 }
 
+// GetInvitationsChangedSince returns the IDs of invitations whose guest updated or withdrew their response after a time
+// A first response is not a change
+func (db *DB) GetInvitationsChangedSince(since time.Time) (map[int64]bool, error) {
+	rows, err := db.Query(
+		`SELECT DISTINCT r.invitation_id
+		 FROM responses r
+		 JOIN invitations i ON i.id = r.invitation_id AND i.deleted_at IS NULL
+		 WHERE r.withdrawn_at > $1
+		    OR (r.submitted_at > $1 AND EXISTS(SELECT 1 FROM responses p WHERE p.invitation_id = r.invitation_id AND p.id < r.id))`,
+		since,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get changed invitations: %w", err)
+	}
+	defer rows.Close()
+
+	changed := make(map[int64]bool)
+	for rows.Next() {
+		var id int64
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("failed to scan changed invitation: %w", err)
+		}
+		changed[id] = true
+	}
+
+	return changed, nil
+}
+
+// invitationWithResponseColumns selects an invitation (i) and its latest response (r), in the order scanInvitationWithResponse expects
+const invitationWithResponseColumns = `i.id, i.guest_name, i.phone, i.token, COALESCE(i.code, ''), i.invite_message, i.sent_at, i.opened_at, i.responded_at, i.revoked_at, i.previewed_at, i.deleted_at, i.created_at,
+			r.id, r.invitation_id, r.attending, r.plus_one, r.plus_one_name, r.guest_name_tag, r.kids_count, r.menu_preference, r.companion_menu_preference, r.comment, r.submitted_at, r.is_latest`
+
 // GetAllInvitationsWithResponses retrieves all invitations with their latest responses
 func (db *DB) GetAllInvitationsWithResponses() ([]*InvitationWithResponse, error) {
 	rows, err := db.Query(
-		`SELECT
-			i.id, i.guest_name, i.phone, i.token, i.invite_message, i.sent_at, i.opened_at, i.responded_at, i.created_at,
-			r.id, r.invitation_id, r.attending, r.plus_one, r.plus_one_name, r.guest_name_tag, r.kids_count, r.menu_preference, r.companion_menu_preference, r.comment, r.submitted_at, r.is_latest
+		`SELECT ` + invitationWithResponseColumns + `
 		 FROM invitations i
 		 LEFT JOIN responses r ON i.id = r.invitation_id AND r.is_latest = TRUE
+		 WHERE i.deleted_at IS NULL
 		 ORDER BY i.created_at DESC`,
 	)
 	if err != nil {
@@ -144,49 +213,59 @@ func (db *DB) GetAllInvitationsWithResponses() ([]*InvitationWithResponse, error
 
 	var results []*InvitationWithResponse
 	for rows.Next() {
-		iwr := &InvitationWithResponse{}
-		var respID sql.NullInt64
-		var respInvID sql.NullInt64
-		var respAttending sql.NullBool
-		var respPlusOne sql.NullBool
-		var respPlusOneName sql.NullString
-		var respGuestNameTag sql.NullString
-		var respKidsCount sql.NullInt64
-		var respMenuPreference sql.NullString
-		var respCompanionMenuPreference sql.NullString
-		var respComment sql.NullString
-		var respSubmittedAt sql.NullTime
-		var respIsLatest sql.NullBool
-
-		err := rows.Scan(
-			&iwr.ID, &iwr.GuestName, &iwr.Phone, &iwr.Token, &iwr.InviteMessage,
-			&iwr.SentAt, &iwr.OpenedAt, &iwr.RespondedAt, &iwr.CreatedAt,
-			&respID, &respInvID, &respAttending, &respPlusOne, &respPlusOneName,
-			&respGuestNameTag, &respKidsCount, &respMenuPreference, &respCompanionMenuPreference, &respComment, &respSubmittedAt, &respIsLatest,
-		)
+		iwr, err := scanInvitationWithResponse(rows)
 		if err != nil {
-			return nil, fmt.Errorf("failed to scan invitation with response: %w", err)
-		}
-
-		if respID.Valid {
-			iwr.Response = &Response{
-				ID:                      respID.Int64,
-				InvitationID:            respInvID.Int64,
-				Attending:               respAttending.Bool,
-				PlusOne:                 respPlusOne.Bool,
-				PlusOneName:             respPlusOneName,
-				GuestNameTag:            respGuestNameTag.String,
-				KidsCount:               int(respKidsCount.Int64),
-				MenuPreference:          respMenuPreference,
-				CompanionMenuPreference: respCompanionMenuPreference,
-				Comment:                 respComment,
-				SubmittedAt:             respSubmittedAt.Time,
-				IsLatest:                respIsLatest.Bool,
-			}
+			return nil, err
 		}
-
 		results = append(results, iwr)
 	}
 
 	return results, nil
 }
+
+// scanInvitationWithResponse scans an invitation joined with its latest response (response columns are NULL without one)
+// Extra destinations receive any columns selected after the response columns
+func scanInvitationWithResponse(rows *sql.Rows, extra ...interface{}) (*InvitationWithResponse, error) {
+	iwr := &InvitationWithResponse{}
+	var respID sql.NullInt64
+	var respInvID sql.NullInt64
+	var respAttending sql.NullBool
+	var respPlusOne sql.NullBool
+	var respPlusOneName sql.NullString
+	var respGuestNameTag sql.NullString
+	var respKidsCount sql.NullInt64
+	var respMenuPreference sql.NullString
+	var respCompanionMenuPreference sql.NullString
+	var respComment sql.NullString
+	var respSubmittedAt sql.NullTime
+	var respIsLatest sql.NullBool
+
+	dest := []interface{}{
+		&iwr.ID, &iwr.GuestName, &iwr.Phone, &iwr.Token, &iwr.Code, &iwr.InviteMessage,
+		&iwr.SentAt, &iwr.OpenedAt, &iwr.RespondedAt, &iwr.RevokedAt, &iwr.PreviewedAt, &iwr.DeletedAt, &iwr.CreatedAt,
+		&respID, &respInvID, &respAttending, &respPlusOne, &respPlusOneName,
+		&respGuestNameTag, &respKidsCount, &respMenuPreference, &respCompanionMenuPreference, &respComment, &respSubmittedAt, &respIsLatest,
+	}
+	if err := rows.Scan(append(dest, extra...)...); err != nil {
+		return nil, fmt.Errorf("failed to scan invitation with response: %w", err)
+	}
+
+	if respID.Valid {
+		iwr.Response = &Response{
+			ID:                      respID.Int64,
+			InvitationID:            respInvID.Int64,
+			Attending:               respAttending.Bool,
+			PlusOne:                 respPlusOne.Bool,
+			PlusOneName:             respPlusOneName,
+			GuestNameTag:            respGuestNameTag.String,
+			KidsCount:               int(respKidsCount.Int64),
+			MenuPreference:          respMenuPreference,
+			CompanionMenuPreference: respCompanionMenuPreference,
+			Comment:                 respComment,
+			SubmittedAt:             respSubmittedAt.Time,
+			IsLatest:                respIsLatest.Bool,
+		}
+	}
+
+	return iwr, nil
+}
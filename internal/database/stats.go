@@ -0,0 +1,182 @@
+package database
+
+import (
+	"fmt"
+	"time"
+)
+
+// DashboardStats holds the attendance statistics shown on the admin dashboard
+// Response-based counts only consider the latest response of each invitation
+type DashboardStats struct {
+	Invitations   int
+	Sent          int
+	Opened        int
+	Responded     int
+	Attending     int
+	Declined      int
+	Companions    int
+	Kids          int
+	StandardMenus int
+	VeganMenus    int
+}
+
+// Headcount returns the total number of people expected (guests + companions + kids)
+func (s *DashboardStats) Headcount() int {
+	return s.Attending + s.Companions + s.Kids
+}
+
+// DailyResponses holds the number of responses submitted on a given day
+type DailyResponses struct {
+	Day   time.Time
+	Count int
+}
+
+// RecentResponse is a response submission together with the invitation it belongs to
+type RecentResponse struct {
+	InvitationID int64
+	GuestName    string
+	Attending    bool
+	PlusOne      bool
+	KidsCount    int
+	SubmittedAt  time.Time
+	IsUpdate     bool
+}
+
+// standardMenu and veganMenu are SQL conditions on a menu preference column
+// Only "vegan" is a vegan menu; a missing or empty preference counts as standard, which is the form default
+func standardMenu(column string) string {
+	return "COALESCE(" + column + ", '') <> 'vegan'"
+}
+
+func veganMenu(column string) string {
+	return column + " = 'vegan'"
+}
+
+// dashboardStatsColumns aggregates invitations (i) and their latest responses (r) into the DashboardStats fields
+var dashboardStatsColumns = `COUNT(*),
+			COUNT(i.sent_at),
+			COUNT(i.opened_at),
+			COUNT(i.responded_at),
+			COUNT(*) FILTER (WHERE r.attending),
+			COUNT(*) FILTER (WHERE r.attending = FALSE),
+			COUNT(*) FILTER (WHERE r.attending AND r.plus_one),
+			COALESCE(SUM(r.kids_count) FILTER (WHERE r.attending), 0),
+			COUNT(*) FILTER (WHERE r.attending AND ` + standardMenu("r.menu_preference") + `)
+				+ COUNT(*) FILTER (WHERE r.attending AND r.plus_one AND ` + standardMenu("r.companion_menu_preference") + `),
+			COUNT(*) FILTER (WHERE r.attending AND ` + veganMenu("r.menu_preference") + `)
+				+ COUNT(*) FILTER (WHERE r.attending AND r.plus_one AND ` + veganMenu("r.companion_menu_preference") + `)`
+
+// scanDest returns the scan destinations of dashboardStatsColumns
+func (s *DashboardStats) scanDest() []interface{} {
+	return []interface{}{&s.Invitations, &s.Sent, &s.Opened, &s.Responded,
+		&s.Attending, &s.Declined, &s.Companions, &s.Kids,
+		&s.StandardMenus, &s.VeganMenus}
+}
+
+// GetDashboardStats computes the dashboard statistics from invitations and latest responses
+func (db *DB) GetDashboardStats() (*DashboardStats, error) {
+	stats := &DashboardStats{}
+	err := db.QueryRow(
+		`SELECT ` + dashboardStatsColumns + `
+		 FROM invitations i
+		 LEFT JOIN responses r ON i.id = r.invitation_id AND r.is_latest = TRUE
+		 WHERE i.deleted_at IS NULL`,
+	).Scan(stats.scanDest()...)
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to get dashboard stats: %w", err)
+	}
+
+	return stats, nil
+}
+
+// GetResponsesPerDay counts all response submissions (including updates) per day
+func (db *DB) GetResponsesPerDay() ([]*DailyResponses, error) {
+	rows, err := db.Query(
+		`SELECT DATE(r.submitted_at) AS day, COUNT(*)
+		 FROM responses r
+		 JOIN invitations i ON i.id = r.invitation_id AND i.deleted_at IS NULL
+		 GROUP BY day
+		 ORDER BY day`,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get responses per day: %w", err)
+	}
+	defer rows.Close()
+
+	var results []*DailyResponses
+	for rows.Next() {
+		daily := &DailyResponses{}
+		if err := rows.Scan(&daily.Day, &daily.Count); err != nil {
+			return nil, fmt.Errorf("failed to scan responses per day: %w", err)
+		}
+		results = append(results, daily)
+	}
+
+	return results, nil
+}
+
+// GetRecentResponses retrieves the most recent response submissions
+// IsUpdate is set when the guest had already responded before
+func (db *DB) GetRecentResponses(limit int) ([]*RecentResponse, error) {
+	rows, err := db.Query(
+		`SELECT
+			i.id, i.guest_name, r.attending, r.plus_one, r.kids_count, r.submitted_at,
+			EXISTS(SELECT 1 FROM responses p WHERE p.invitation_id = r.invitation_id AND p.id < r.id)
+		 FROM responses r
+		 JOIN invitations i ON i.id = r.invitation_id
+		 WHERE i.deleted_at IS NULL
+		 ORDER BY r.submitted_at DESC, r.id DESC
+		 LIMIT $1`,
+		limit,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get recent responses: %w", err)
+	}
+	defer rows.Close()
+
+	var results []*RecentResponse
+	for rows.Next() {
+		recent := &RecentResponse{}
+		err := rows.Scan(&recent.InvitationID, &recent.GuestName, &recent.Attending, &recent.PlusOne,
+			&recent.KidsCount, &recent.SubmittedAt, &recent.IsUpdate)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan recent response: %w", err)
+		}
+		results = append(results, recent)
+	}
+
+	return results, nil
+}
+
+// CateringCounts holds the menu counts of the latest attending responses
+// Menus are counted with the same rules as the dashboard (see standardMenu)
+type CateringCounts struct {
+	GuestStandard     int
+	GuestVegan        int
+	CompanionStandard int
+	CompanionVegan    int
+	Children          int
+}
+
+// GetCateringCounts aggregates menu preferences and kids across latest attending responses
+func (db *DB) GetCateringCounts() (*CateringCounts, error) {
+	counts := &CateringCounts{}
+	err := db.QueryRow(
+		`SELECT
+			COUNT(*) FILTER (WHERE `+standardMenu("menu_preference")+`),
+			COUNT(*) FILTER (WHERE `+veganMenu("menu_preference")+`),
+			COUNT(*) FILTER (WHERE plus_one AND `+standardMenu("companion_menu_preference")+`),
+			COUNT(*) FILTER (WHERE plus_one AND `+veganMenu("companion_menu_preference")+`),
+			COALESCE(SUM(kids_count), 0)
+		 FROM responses
+		 WHERE is_latest = TRUE AND attending = TRUE
+		   AND invitation_id IN (SELECT id FROM invitations WHERE deleted_at IS NULL)`,
+	).Scan(&counts.GuestStandard, &counts.GuestVegan, &counts.CompanionStandard, &counts.CompanionVegan, &counts.Children)
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to get catering counts: %w", err)
+	}
+
+	return counts, nil
+}
@@ -0,0 +1,182 @@
+package database
+
+import (
+	"database/sql"
+	"fmt"
+)
+
+// TagStats holds the dashboard statistics of the invitations with a tag
+type TagStats struct {
+	Tag string
+	DashboardStats
+}
+
+// ensureTag returns the ID of a tag, creating it when it does not exist yet (names are matched case-insensitively)
+func ensureTag(tx *sql.Tx, name string) (int64, error) {
+	var id int64
+	err := tx.QueryRow(
+		`INSERT INTO tags (name) VALUES ($1)
+		 ON CONFLICT (LOWER(name)) DO UPDATE SET name = tags.name
+		 RETURNING id`,
+		name,
+	).Scan(&id)
+	if err != nil {
+		return 0, fmt.Errorf("failed to create tag: %w", err)
+	}
+	return id, nil
+}
+
+// GetTagNames retrieves the tags used by at least one invitation that is not archived, alphabetically
+func (db *DB) GetTagNames() ([]string, error) {
+	rows, err := db.Query(
+		`SELECT t.name FROM tags t
+		 WHERE EXISTS (
+			SELECT 1 FROM invitation_tags it JOIN invitations i ON i.id = it.invitation_id
+			WHERE it.tag_id = t.id AND i.deleted_at IS NULL
+		 )
+		 ORDER BY LOWER(t.name)`,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tags: %w", err)
+	}
+	defer rows.Close()
+
+	var names []string
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			return nil, fmt.Errorf("failed to scan tag: %w", err)
+		}
+		names = append(names, name)
+	}
+
+	return names, nil
+}
+
+// GetInvitationTags retrieves the tags of an invitation, alphabetically
+func (db *DB) GetInvitationTags(invitationID int64) ([]string, error) {
+	tags, err := db.getTagsByInvitation(`WHERE it.invitation_id = $1`, invitationID)
+	if err != nil {
+		return nil, err
+	}
+	return tags[invitationID], nil
+}
+
+// GetAllInvitationTags retrieves the tags of every invitation, alphabetically, keyed by invitation ID
+func (db *DB) GetAllInvitationTags() (map[int64][]string, error) {
+	return db.getTagsByInvitation("")
+}
+
+// getTagsByInvitation retrieves invitation tags matching a WHERE clause on invitation_tags (it), keyed by invitation ID
+func (db *DB) getTagsByInvitation(where string, args ...interface{}) (map[int64][]string, error) {
+	rows, err := db.Query(
+		`SELECT it.invitation_id, t.name
+		 FROM invitation_tags it JOIN tags t ON t.id = it.tag_id
+		 `+where+`
+		 ORDER BY LOWER(t.name)`,
+		args...,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get invitation tags: %w", err)
+	}
+	defer rows.Close()
+
+	tags := make(map[int64][]string)
+	for rows.Next() {
+		var invitationID int64
+		var name string
+		if err := rows.Scan(&invitationID, &name); err != nil {
+			return nil, fmt.Errorf("failed to scan invitation tag: %w", err)
+		}
+		tags[invitationID] = append(tags[invitationID], name)
+	}
+
+	return tags, nil
+}
+
+// setInvitationTags replaces the tags of an invitation inside a transaction
+func setInvitationTags(tx *sql.Tx, invitationID int64, names []string) error {
+	if _, err := tx.Exec(`DELETE FROM invitation_tags WHERE invitation_id = $1`, invitationID); err != nil {
+		return fmt.Errorf("failed to clear invitation tags: %w", err)
+	}
+
+	for _, name := range names {
+		tagID, err := ensureTag(tx, name)
+		if err != nil {
+			return err
+		}
+		_, err = tx.Exec(
+			`INSERT INTO invitation_tags (invitation_id, tag_id) VALUES ($1, $2) ON CONFLICT DO NOTHING`,
+			invitationID, tagID,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to tag invitation: %w", err)
+		}
+	}
+	return nil
+}
+
+// BulkAddTags adds tags to the given invitations, skipping archived ones
+// Invitations that already have some of the tags keep them and count as tagged
+func (db *DB) BulkAddTags(ids []int64, names []string) (*BulkResult, error) {
+	var tagIDs []int64
+	return db.applyBulk(ids,
+		func(inv *Invitation) string {
+			if inv.DeletedAt.Valid {
+				return BulkSkipArchived
+			}
+			return ""
+		},
+		func(tx *sql.Tx, inv *Invitation) error {
+			if tagIDs == nil {
+				for _, name := range names {
+					id, err := ensureTag(tx, name)
+					if err != nil {
+						return err
+					}
+					tagIDs = append(tagIDs, id)
+				}
+			}
+			for _, tagID := range tagIDs {
+				_, err := tx.Exec(
+					`INSERT INTO invitation_tags (invitation_id, tag_id) VALUES ($1, $2) ON CONFLICT DO NOTHING`,
+					inv.ID, tagID,
+				)
+				if err != nil {
+					return fmt.Errorf("failed to tag invitation: %w", err)
+				}
+			}
+			return nil
+		},
+	)
+}
+
+// GetDashboardStatsByTag computes the dashboard statistics of each tag's invitations, alphabetically
+// An invitation with several tags counts in each of them
+func (db *DB) GetDashboardStatsByTag() ([]*TagStats, error) {
+	rows, err := db.Query(
+		`SELECT t.name, ` + dashboardStatsColumns + `
+		 FROM tags t
+		 JOIN invitation_tags it ON it.tag_id = t.id
+		 JOIN invitations i ON i.id = it.invitation_id
+		 LEFT JOIN responses r ON i.id = r.invitation_id AND r.is_latest = TRUE
+		 WHERE i.deleted_at IS NULL
+		 GROUP BY t.id, t.name
+		 ORDER BY LOWER(t.name)`,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tag stats: %w", err)
+	}
+	defer rows.Close()
+
+	var results []*TagStats
+	for rows.Next() {
+		stats := &TagStats{}
+		if err := rows.Scan(append([]interface{}{&stats.Tag}, stats.DashboardStats.scanDest()...)...); err != nil {
+			return nil, fmt.Errorf("failed to scan tag stats: %w", err)
+		}
+		results = append(results, stats)
+	}
+
+	return results, nil
+}
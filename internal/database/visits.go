@@ -0,0 +1,166 @@
+package database
+
+import (
+	"fmt"
+	"sort"
+	"time"
+)
+
+// Visit is a guest opening their invitation page
+// FormStarted is set once the guest starts filling in the RSVP form during the visit
+type Visit struct {
+	ID           int64
+	InvitationID int64
+	Language     string
+	Device       string
+	FormStarted  bool
+	VisitedAt    time.Time
+}
+
+// Kinds of entries in an invitation's activity timeline
+const (
+	ActivityCreated   = "created"
+	ActivitySent      = "sent"
+	ActivityPreviewed = "previewed"
+	ActivityVisit     = "visit"
+	ActivityResponse  = "response"
+	ActivityWithdrawn = "withdrawn"
+	ActivityRevoked   = "revoked"
+)
+
+// ActivityEvent is an entry in an invitation's activity timeline
+// Visit or Response is set for visit and response entries
+type ActivityEvent struct {
+	Kind     string
+	At       time.Time
+	Visit    *Visit
+	Response *Response
+}
+
+// FollowUp is an invitation that was opened but not answered yet
+type FollowUp struct {
+	InvitationID int64
+	GuestName    string
+	Phone        string
+	Visits       int
+	FormStarted  bool
+	LastVisitAt  time.Time
+}
+
+// CreateVisit records a visit to an invitation page and returns it with its ID
+func (db *DB) CreateVisit(invitationID int64, language, device string) (*Visit, error) {
+	visit := &Visit{InvitationID: invitationID, Language: language, Device: device}
+	err := db.QueryRow(
+		`INSERT INTO invitation_visits (invitation_id, language, device) VALUES ($1, $2, $3)
+		 RETURNING id, visited_at`,
+		invitationID, language, device,
+	).Scan(&visit.ID, &visit.VisitedAt)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create visit: %w", err)
+	}
+	return visit, nil
+}
+
+// MarkVisitFormStarted records that the guest started the RSVP form during a visit
+// The token must belong to the visit's invitation so that visits of other guests can't be changed
+func (db *DB) MarkVisitFormStarted(visitID int64, token string) error {
+	_, err := db.Exec(
+		`UPDATE invitation_visits SET form_started = TRUE
+		 WHERE id = $1 AND invitation_id = (SELECT id FROM invitations WHERE token = $2)`,
+		visitID, token,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to mark visit form as started: %w", err)
+	}
+	return nil
+}
+
+// GetVisitsByInvitationID retrieves all visits of an invitation, newest first
+func (db *DB) GetVisitsByInvitationID(invitationID int64) ([]*Visit, error) {
+	rows, err := db.Query(
+		`SELECT id, invitation_id, language, device, form_started, visited_at
+		 FROM invitation_visits WHERE invitation_id = $1 ORDER BY visited_at DESC, id DESC`,
+		invitationID,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get visits: %w", err)
+	}
+	defer rows.Close()
+
+	var visits []*Visit
+	for rows.Next() {
+		visit := &Visit{}
+		err := rows.Scan(&visit.ID, &visit.InvitationID, &visit.Language, &visit.Device, &visit.FormStarted, &visit.VisitedAt)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan visit: %w", err)
+		}
+		visits = append(visits, visit)
+	}
+
+	return visits, nil
+}
+
+// GetInvitationActivity builds the activity timeline of an invitation, newest first
+func (db *DB) GetInvitationActivity(invitation *Invitation) ([]*ActivityEvent, error) {
+	visits, err := db.GetVisitsByInvitationID(invitation.ID)
+	if err != nil {
+		return nil, err
+	}
+	responses, err := db.GetAllResponsesByInvitationID(invitation.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	events := []*ActivityEvent{{Kind: ActivityCreated, At: invitation.CreatedAt}}
+	if invitation.SentAt.Valid {
+		events = append(events, &ActivityEvent{Kind: ActivitySent, At: invitation.SentAt.Time})
+	}
+	if invitation.PreviewedAt.Valid {
+		events = append(events, &ActivityEvent{Kind: ActivityPreviewed, At: invitation.PreviewedAt.Time})
+	}
+	if invitation.RevokedAt.Valid {
+		events = append(events, &ActivityEvent{Kind: ActivityRevoked, At: invitation.RevokedAt.Time})
+	}
+	for _, visit := range visits {
+		events = append(events, &ActivityEvent{Kind: ActivityVisit, At: visit.VisitedAt, Visit: visit})
+	}
+	for _, response := range responses {
+		events = append(events, &ActivityEvent{Kind: ActivityResponse, At: response.SubmittedAt, Response: response})
+		if response.WithdrawnAt.Valid {
+			events = append(events, &ActivityEvent{Kind: ActivityWithdrawn, At: response.WithdrawnAt.Time})
+		}
+	}
+
+	sort.SliceStable(events, func(i, j int) bool { return events[i].At.After(events[j].At) })
+	return events, nil
+}
+
+// GetOpenedWithoutResponse retrieves invitations that guests visited but haven't answered,
+// most recently visited first; revoked and archived invitations are left out
+func (db *DB) GetOpenedWithoutResponse() ([]*FollowUp, error) {
+	rows, err := db.Query(
+		`SELECT i.id, i.guest_name, i.phone, COUNT(v.id), BOOL_OR(v.form_started), MAX(v.visited_at)
+		 FROM invitations i
+		 JOIN invitation_visits v ON v.invitation_id = i.id
+		 WHERE i.responded_at IS NULL AND i.revoked_at IS NULL AND i.deleted_at IS NULL
+		 GROUP BY i.id, i.guest_name, i.phone
+		 ORDER BY MAX(v.visited_at) DESC`,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get opened invitations without response: %w", err)
+	}
+	defer rows.Close()
+
+	var results []*FollowUp
+	for rows.Next() {
+		followUp := &FollowUp{}
+		err := rows.Scan(&followUp.InvitationID, &followUp.GuestName, &followUp.Phone,
+			&followUp.Visits, &followUp.FormStarted, &followUp.LastVisitAt)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan follow-up: %w", err)
+		}
+		results = append(results, followUp)
+	}
+
+	return results, nil
+}
@@ -0,0 +1,875 @@
+package i18n
+
+// adminMessages holds the translations used by the admin interface.
+// Every key must exist in both languages (enforced by TestAdminMessagesComplete).
+var adminMessages = map[Language]map[string]string{
+	Romanian: {
+		// Navigation
+		"nav.dashboard":   "Dashboard",
+		"nav.invitations": "Invitații",
+		"nav.catering":    "Catering",
+		"nav.logout":      "Deconectare",
+		"nav.audit":       "Jurnal",
+		"nav.admins":      "Administratori",
+		"nav.sessions":    "Sesiuni",
+		"nav.security":    "Securitate",
+		"nav.cards":       "Cartonașe",
+		"nav.checkin":     "Check-in",
+		"nav.switch_lang": "English",
+
+		// Dashboard
+		"dashboard.title":          "Dashboard - Evite Admin",
+		"dashboard.heading":        "Dashboard Admin",
+		"dashboard.welcome":        "Bun venit, %s (%s)",
+		"dashboard.invitations":    "Invitații",
+		"dashboard.sent":           "Trimise",
+		"dashboard.opened":         "Deschise",
+		"dashboard.responded":      "Au răspuns",
+		"dashboard.out_of":         "din %d (%d%%)",
+		"dashboard.attending":      "Participă",
+		"dashboard.declined":       "Nu participă",
+		"dashboard.pending":        "Fără răspuns",
+		"dashboard.headcount":      "Total persoane",
+		"dashboard.headcount_desc": "%d invitați + %d însoțitori + %d copii",
+		"dashboard.menus":          "Meniuri adulți",
+		"dashboard.menu_standard":  "Standard",
+		"dashboard.menu_vegan":     "Vegan",
+		"dashboard.over_time":      "Răspunsuri în timp",
+		"dashboard.no_responses":   "Nu există răspunsuri încă.",
+		"dashboard.recent":         "Modificări recente",
+		"dashboard.col_when":       "Când",
+		"dashboard.new_response":   "Răspuns nou",
+		"dashboard.updated":        "Răspuns actualizat",
+		"dashboard.follow_up":      "De urmărit: deschise fără răspuns",
+		"dashboard.follow_help":    "Invitați care au deschis invitația, dar nu au răspuns încă.",
+		"dashboard.no_follow_up":   "Nicio invitație deschisă fără răspuns.",
+		"dashboard.col_visits":     "Vizite",
+		"dashboard.col_last_visit": "Ultima vizită",
+		"dashboard.follow_list":    "Deschide lista pentru reamintiri",
+		"dashboard.by_tag":         "Pe grupuri",
+		"dashboard.col_tag":        "Grup",
+
+		// Catering report
+		"catering.title":          "Catering - Evite Admin",
+		"catering.heading":        "Raport catering",
+		"catering.adult_standard": "Adulți - meniu standard",
+		"catering.adult_vegan":    "Adulți - meniu vegan",
+		"catering.children":       "Copii",
+		"catering.col_category":   "Categorie",
+		"catering.col_count":      "Persoane",
+		"catering.col_price":      "Preț / persoană",
+		"catering.col_subtotal":   "Subtotal",
+		"catering.total":          "Total estimat",
+		"catering.breakdown":      "Adulții includ %d invitați și %d însoțitori.",
+		"catering.note":           "Include doar invitații care au confirmat prezența (ultimul răspuns).",
+		"catering.print":          "Printează / Salvează PDF",
+		"catering.generated":      "Generat la %s",
+
+		// Audit log
+		"audit.title":                           "Jurnal modificări - Evite Admin",
+		"audit.heading":                         "Jurnal modificări",
+		"audit.filter":                          "Filtrează",
+		"audit.reset":                           "Resetează",
+		"audit.all_actions":                     "Toate acțiunile",
+		"audit.empty":                           "Nu există înregistrări pentru filtrele alese.",
+		"audit.limited":                         "Sunt afișate cele mai recente %d înregistrări. Exportul CSV le conține pe toate.",
+		"audit.col_when":                        "Când",
+		"audit.col_actor":                       "Administrator",
+		"audit.col_action":                      "Acțiune",
+		"audit.col_invitation":                  "ID invitație",
+		"audit.col_target":                      "Invitat",
+		"audit.col_changes":                     "Modificări",
+		"audit.col_before":                      "Înainte",
+		"audit.col_after":                       "După",
+		"audit.col_ip":                          "IP",
+		"audit.from":                            "De la",
+		"audit.to":                              "Până la",
+		"audit.action.invitation.create":        "Invitație creată",
+		"audit.action.invitation.update":        "Invitație modificată",
+		"audit.action.invitation.archive":       "Invitație arhivată",
+		"audit.action.invitation.restore":       "Invitație restaurată",
+		"audit.action.invitation.delete":        "Invitație ștearsă definitiv",
+		"audit.action.invitation.mark_sent":     "Marcată ca trimisă",
+		"audit.action.invitation.new_link":      "Link nou generat",
+		"audit.action.invitation.revoke":        "Link revocat",
+		"audit.action.invitation.tag":           "Etichetă adăugată",
+		"audit.action.invitation.check_in":      "Check-in făcut",
+		"audit.action.invitation.undo_check_in": "Check-in anulat",
+		"audit.action.export.csv":               "Export CSV invitații",
+		"audit.action.export.audit_csv":         "Export CSV jurnal",
+		"audit.action.export.qr_zip":            "Export coduri QR",
+		"audit.action.export.cards":             "Export cartonașe",
+		"audit.action.admin.language":           "Limbă schimbată",
+		"audit.action.admin.create":             "Administrator adăugat",
+		"audit.action.admin.role":               "Rol schimbat",
+		"audit.action.admin.delete":             "Administrator eliminat",
+		"audit.action.admin.session_revoke":     "Sesiune închisă",
+
+		"checkin.title":       "Check-in - Evite Admin",
+		"checkin.heading":     "Check-in",
+		"checkin.help":        "Invitații care au confirmat prezența. Caută după nume și apasă Check-in când ajung.",
+		"checkin.search":      "Caută",
+		"checkin.search_hint": "Numele invitatului sau al însoțitorului",
+		"checkin.arrived":     "Persoane sosite",
+		"checkin.empty":       "Niciun invitat găsit",
+		"checkin.col_party":   "Persoane",
+		"checkin.col_status":  "Stare",
+		"checkin.arrived_at":  "Sosit la %s",
+		"checkin.expected":    "Așteptat",
+		"checkin.check_in":    "Check-in",
+		"checkin.undo":        "Anulează",
+
+		"sessions.title":         "Sesiuni - Evite Admin",
+		"sessions.heading":       "Sesiuni active",
+		"sessions.all_help":      "Dispozitivele pe care administratorii sunt autentificați acum. Poți închide orice sesiune de la distanță.",
+		"sessions.own_help":      "Dispozitivele pe care ești autentificat acum. Închide orice sesiune pe care nu o recunoști.",
+		"sessions.empty":         "Nu există sesiuni active.",
+		"sessions.col_admin":     "Administrator",
+		"sessions.col_device":    "Dispozitiv",
+		"sessions.col_ip":        "IP",
+		"sessions.col_last_seen": "Ultima activitate",
+		"sessions.col_signed_in": "Autentificat la",
+		"sessions.this_device":   "acest dispozitiv",
+		"sessions.sign_out":      "Deconectează",
+
+		"security.title":                 "Securitate - Evite Admin",
+		"security.heading":               "Blocări și boți",
+		"security.help":                  "Clienții care au depășit limitele de cereri pe paginile publice și trimiterile blocate de capcana pentru boți.",
+		"security.empty":                 "Nu a fost blocat nimic.",
+		"security.col_kind":              "Tip",
+		"security.col_key":               "IP / token",
+		"security.col_path":              "Pagină",
+		"security.col_user_agent":        "Browser",
+		"security.kind.rate_limit_ip":    "Prea multe cereri de la un IP",
+		"security.kind.rate_limit_token": "Prea multe linkuri sau coduri de invitație inexistente",
+		"security.kind.rate_limit_write": "Prea multe confirmări pentru aceeași invitație",
+		"security.kind.honeypot":         "Trimitere de bot ignorată",
+
+		// Printable cards
+		"cards.title":       "Cartonașe - Evite Admin",
+		"cards.heading":     "Cartonașe de invitație",
+		"cards.help":        "Generează cartonașe personalizate de tipărit, cu numele invitatului, detaliile evenimentului și un cod QR pentru linkul de răspuns. Dacă nu bifezi nicio invitație, se generează pentru toate.",
+		"cards.design":      "Model",
+		"cards.design_card": "Cartonaș",
+		"cards.design_long": "Cartonaș lung",
+		"cards.language":    "Limba cartonașului",
+		"cards.select_all":  "Selectează tot",
+		"cards.download":    "Descarcă (PNG / ZIP)",
+		"cards.empty":       "Nu există invitații cu link activ.",
+		"cards.card":        "Cartonaș de tipărit",
+
+		// Invitation activity
+		"activity.title":          "Activitate - Evite Admin",
+		"activity.heading":        "Activitate: %s",
+		"activity.help":           "Fiecare vizită a invitatului pe pagina invitației, împreună cu trimiterea și răspunsurile.",
+		"activity.visits":         "%d vizite",
+		"activity.created":        "Invitație creată",
+		"activity.sent":           "Marcată ca trimisă",
+		"activity.previewed":      "Link previzualizat automat (WhatsApp, Telegram etc.)",
+		"activity.visit":          "Vizită",
+		"activity.form_started":   "a început formularul",
+		"activity.no_form":        "nu a început formularul",
+		"activity.response":       "Răspuns trimis",
+		"activity.withdrawn":      "Răspuns retras de invitat",
+		"activity.revoked":        "Link revocat",
+		"activity.device_mobile":  "telefon",
+		"activity.device_tablet":  "tabletă",
+		"activity.device_desktop": "calculator",
+		"activity.device_unknown": "dispozitiv necunoscut",
+
+		// Response history
+		"history.title":          "Istoric răspunsuri - Evite Admin",
+		"history.heading":        "Istoric răspunsuri: %s",
+		"history.help":           "Toate răspunsurile trimise de invitat, în ordine cronologică, cu ce s-a schimbat la fiecare.",
+		"history.link":           "Istoric răspunsuri",
+		"history.empty":          "Invitatul nu a răspuns încă.",
+		"history.first":          "Primul răspuns",
+		"history.no_changes":     "Retrimis fără modificări",
+		"history.current":        "Actual",
+		"history.withdrawn":      "Retras pe %s",
+		"history.yes":            "da",
+		"history.no":             "nu",
+		"history.attending":      "Participă",
+		"history.name":           "Nume",
+		"history.plus_one":       "Însoțitor",
+		"history.kids":           "Copii",
+		"history.menu":           "Meniu",
+		"history.companion_menu": "Meniu însoțitor",
+		"history.comment":        "Mesaj",
+
+		// Archive
+		"archive.title":         "Arhivă - Evite Admin",
+		"archive.heading":       "Invitații arhivate",
+		"archive.link":          "Arhivă",
+		"archive.help":          "Invitațiile șterse rămân aici până le restaurezi sau le ștergi definitiv. Nu apar în statistici și exporturi, iar linkurile lor nu mai funcționează.",
+		"archive.help_purge":    "Invitațiile șterse rămân aici %d zile, apoi sunt șterse definitiv. Nu apar în statistici și exporturi, iar linkurile lor nu mai funcționează.",
+		"archive.empty":         "Arhiva este goală.",
+		"archive.archived_at":   "Arhivată",
+		"archive.purge_on":      "Ștergere definitivă",
+		"archive.purge":         "Șterge definitiv",
+		"archive.confirm_purge": "Invitația și toate răspunsurile ei vor fi șterse definitiv. Continui?",
+		"archive.phone_taken":   "Nu se poate restaura: o altă invitație folosește deja acest număr de telefon.",
+
+		// Bulk actions
+		"bulk.title":             "Acțiuni în masă - Evite Admin",
+		"bulk.selected":          "%d selectate",
+		"bulk.select_all":        "Selectează tot",
+		"bulk.page_selected":     "Toate cele %d invitații de pe această pagină sunt selectate.",
+		"bulk.select_matching":   "Selectează toate cele %d invitații găsite",
+		"bulk.all_selected":      "Toate cele %d invitații găsite sunt selectate.",
+		"bulk.clear":             "Anulează selecția",
+		"bulk.mark_sent":         "Marchează ca trimise",
+		"bulk.send_whatsapp":     "Trimite pe WhatsApp",
+		"bulk.send_sms":          "Trimite prin SMS",
+		"bulk.export":            "Exportă CSV",
+		"bulk.archive":           "Șterge",
+		"bulk.confirm_arch":      "Sigur vrei să ștergi invitațiile selectate? Le poți restaura din arhivă.",
+		"bulk.done_mark_sent":    "%d invitații marcate ca trimise.",
+		"bulk.done_archive":      "%d invitații mutate în arhivă.",
+		"bulk.done_tag":          "%d invitații etichetate.",
+		"bulk.tag":               "Adaugă etichetele",
+		"bulk.tag_ph":            "Etichete, cu virgulă",
+		"bulk.skipped":           "%d invitații sărite",
+		"bulk.skip_not_found":    "nu a fost găsită",
+		"bulk.skip_archived":     "este în arhivă",
+		"bulk.skip_already_sent": "era deja trimisă",
+		"bulk.skip_revoked":      "linkul este revocat",
+		"bulk.send_help":         "Deschide mesajul fiecărui invitat și trimite-l, apoi marchează-le pe toate ca trimise.",
+		"bulk.open":              "Deschide mesajul",
+		"bulk.mark_all_sent":     "Marchează toate cele %d ca trimise",
+
+		// Invitations list search, filters and pages
+		"list.search":             "Caută",
+		"list.search_hint":        "Nume, telefon sau nume pe ecuson",
+		"list.status":             "Filtru",
+		"list.tag":                "Grup",
+		"list.all":                "Toate",
+		"list.status_not_sent":    "Netrimise",
+		"list.status_not_opened":  "Trimise, nedeschise",
+		"list.status_no_response": "Deschise, fără răspuns",
+		"list.status_attending":   "Participă",
+		"list.status_declined":    "Nu participă",
+		"list.status_vegan":       "Meniu vegan",
+		"list.status_kids":        "Cu copii",
+		"list.sort":               "Sortare",
+		"list.sort_created":       "Data creării",
+		"list.sort_name":          "Nume",
+		"list.sort_sent":          "Data trimiterii",
+		"list.sort_responded":     "Data răspunsului",
+		"list.asc":                "Crescător",
+		"list.desc":               "Descrescător",
+		"list.apply":              "Aplică",
+		"list.reset":              "Resetează",
+		"list.count":              "%d din %d invitații",
+		"list.no_matches":         "Nicio invitație nu corespunde filtrelor.",
+		"list.first":              "← Prima pagină",
+		"list.next":               "Pagina următoare →",
+
+		// Guest page preview
+		"preview.banner":          "Previzualizare ca invitat: nimic nu este înregistrat și răspunsul nu poate fi trimis",
+		"preview.deadline_open":   "Înainte de termen",
+		"preview.deadline_passed": "După termen",
+		"preview.response":        "Cu răspunsul actual",
+		"preview.no_response":     "Fără răspuns",
+		"preview.form":            "Formular",
+		"preview.submitted":       "După răspuns",
+		"preview.exit":            "Înapoi în admin",
+
+		"admins.title":            "Administratori - Evite Admin",
+		"admins.heading":          "Administratori",
+		"admins.invite_heading":   "Invită un co-organizator",
+		"admins.invite_help":      "După ce îl adaugi, se poate autentifica la %s cu contul Google asociat acestui email.",
+		"admins.invite":           "Invită",
+		"admins.col_email":        "Email",
+		"admins.col_name":         "Nume",
+		"admins.col_role":         "Rol",
+		"admins.col_invited_by":   "Invitat de",
+		"admins.col_last_login":   "Ultima autentificare",
+		"admins.never":            "Niciodată",
+		"admins.you":              "tu",
+		"admins.save_role":        "Salvează",
+		"admins.remove":           "Elimină",
+		"admins.confirm_remove":   "Sigur vrei să elimini acest administrator?",
+		"admins.error_email":      "Introdu o adresă de email validă",
+		"admins.error_role":       "Rol invalid",
+		"admins.error_exists":     "Această adresă este deja administrator",
+		"admins.error_last_owner": "Trebuie să rămână cel puțin un proprietar",
+		"admins.error_self":       "Nu te poți elimina pe tine însuți",
+		"role.owner":              "Proprietar",
+		"role.editor":             "Editor",
+		"role.viewer":             "Vizualizare",
+		"role.checkin":            "Check-in",
+		"role.owner_desc":         "Acces complet, inclusiv ștergeri, jurnal și administratori",
+		"role.editor_desc":        "Creează și modifică invitații, vede rapoartele",
+		"role.viewer_desc":        "Doar vizualizare și rapoarte",
+		"role.checkin_desc":       "Doar face check-in-ul invitaților la intrare",
+
+		"notfound.title":   "Pagină negăsită",
+		"notfound.heading": "Pagina nu există",
+		"notfound.text":    "Linkul este greșit sau incomplet. Dacă ai primit o invitație, deschide din nou linkul din mesaj sau introdu codul invitației pe pagina principală.",
+		"notfound.home":    "Pagina principală",
+
+		"login.title":              "Autentificare - Evite Admin",
+		"login.heading":            "Autentificare administrator",
+		"login.with_google":        "Continuă cu Google",
+		"login.with_oidc":          "Continuă cu contul organizației",
+		"login.or":                 "sau",
+		"login.email":              "Adresa de email",
+		"login.send_link":          "Trimite-mi un link de autentificare",
+		"login.email_help":         "Primești pe email un link valabil o singură dată, timp de câteva minute.",
+		"login.sent":               "Dacă adresa aparține unui administrator, vei primi în curând un email cu linkul de autentificare.",
+		"login.confirm_heading":    "Confirmă autentificarea",
+		"login.confirm_text":       "Apasă butonul de mai jos pentru a intra în panoul de administrare.",
+		"login.confirm":            "Intră în cont",
+		"login.error_email":        "Introdu o adresă de email validă",
+		"login.error_rate_limited": "Prea multe cereri. Încearcă din nou mai târziu.",
+		"login.error_invalid_link": "Linkul de autentificare este invalid, a expirat sau a fost deja folosit. Cere unul nou.",
+		"login.email_subject":      "Linkul tău de autentificare Evite",
+		"login.email_body":         "Bună,\n\nFolosește linkul de mai jos pentru a intra în panoul de administrare Evite:\n\n%s\n\nLinkul poate fi folosit o singură dată și expiră în %d minute.\nDacă nu ai cerut acest email, îl poți ignora.\n",
+
+		// Invitations list
+		"invitations.title":         "Invitații - Evite Admin",
+		"invitations.heading":       "Lista Invitații",
+		"invitations.download_csv":  "Descarcă CSV",
+		"invitations.download_qr":   "Coduri QR (ZIP)",
+		"invitations.qr_title":      "Descarcă codul QR al linkului",
+		"invitations.new":           "+ Invitație Nouă",
+		"invitations.new_short":     "+ Nouă",
+		"invitations.empty":         "Nu există invitații încă.",
+		"invitations.create_first":  "Creează prima invitație",
+		"invitations.col_guest":     "Invitat",
+		"invitations.col_phone":     "Telefon",
+		"invitations.code":          "Cod invitație",
+		"invitations.col_status":    "Status",
+		"invitations.col_response":  "Răspuns",
+		"invitations.col_info":      "Info",
+		"invitations.col_actions":   "Acțiuni",
+		"invitations.confirm_del":   "Sigur vrei să ștergi această invitație? O poți restaura din arhivă.",
+		"invitations.message_from":  "Mesaj de la %s",
+		"invitations.copied":        "Mesaj copiat!",
+		"invitations.copy_title":    "Copiază mesaj invitație",
+		"invitations.mark_sent":     "Marchează ca trimis",
+		"invitations.new_link":      "Generează un link nou (linkul vechi nu va mai funcționa)",
+		"invitations.confirm_new":   "Generezi un link nou? Linkul vechi nu va mai funcționa și va trebui să trimiți din nou mesajul.",
+		"invitations.revoke":        "Revocă linkul invitației",
+		"invitations.confirm_rev":   "Revoci linkul? Invitatul nu va mai putea răspunde până nu generezi un link nou.",
+		"invitations.changed":       "Răspuns modificat",
+		"invitations.changed_since": "Evidențiază răspunsurile modificate după",
+		"invitations.changed_count": "%d invitații cu răspuns modificat",
+		"invitations.view_message":  "Vezi mesaj",
+		"invitations.kids_count":    "%d copii",
+		"invitations.menu":          "Meniu",
+		"invitations.companion":     "Însoțitor",
+		"invitations.menu_short":    "M",
+		"invitations.companion_sh":  "Î",
+		"invitations.attending":     "✓ Participă",
+		"invitations.not_attending": "✗ Nu participă",
+
+		// Status badges
+		"status.sent":            "Trimis",
+		"status.not_sent":        "Netrimis",
+		"status.opened":          "Deschis",
+		"status.responded":       "Răspuns",
+		"status.revoked":         "Revocat",
+		"status.previewed":       "Previzualizat",
+		"status.previewed_help":  "Linkul a fost accesat doar de o previzualizare automată (WhatsApp, Telegram etc.), nu de invitat",
+		"status.sent_short":      "T",
+		"status.opened_short":    "D",
+		"status.responded_short": "R",
+
+		// Common actions
+		"action.message": "Mesaj",
+		"action.history": "Activitate",
+		"action.preview": "Previzualizare",
+		"action.close":   "Închide",
+		"action.copy":    "Copiază",
+		"action.sent":    "Trimis",
+		"action.revoke":  "Revocă",
+		"action.renew":   "Link nou",
+		"action.edit":    "Editează",
+		"action.delete":  "Șterge",
+		"action.restore": "Restaurează",
+		"action.back":    "← Înapoi la listă",
+		"action.cancel":  "Anulează",
+
+		// Invitation form
+		"form.new_title":        "Invitație Nouă - Evite Admin",
+		"form.new_heading":      "Invitație Nouă",
+		"form.edit_title":       "Editează Invitație - Evite Admin",
+		"form.edit_heading":     "Editează Invitație",
+		"form.guest_name":       "Nume Invitat *",
+		"form.guest_name_ph":    "ex: Ion Popescu",
+		"form.guest_name_help":  "Numele complet al invitatului",
+		"form.phone":            "Telefon *",
+		"form.phone_ph":         "ex: +40712345678",
+		"form.phone_help":       "Număr de telefon unic pentru fiecare invitat",
+		"form.tags":             "Grupuri",
+		"form.tags_ph":          "ex: nași, familia mirelui, colegi",
+		"form.tags_help":        "Etichete separate prin virgulă, pentru planificare, filtre și trimiteri în masă",
+		"form.create":           "Creează Invitație",
+		"form.update":           "Actualizează Invitație",
+		"error.form_invalid":    "Eroare la procesarea formularului",
+		"error.required_fields": "Toate câmpurile sunt obligatorii",
+		"error.invalid_phone":   "Număr de telefon invalid",
+		"error.phone_exists":    "Acest număr de telefon există deja",
+		"error.create_failed":   "Eroare la crearea invitației",
+		"error.update_failed":   "Eroare la actualizare. Verifică dacă numărul de telefon nu este deja folosit.",
+
+		// CSV export
+		"csv.name":           "Nume",
+		"csv.phone":          "Telefon",
+		"csv.code":           "Cod",
+		"csv.sent":           "Trimis",
+		"csv.opened":         "Deschis",
+		"csv.responded":      "Răspuns",
+		"csv.attending":      "Participă",
+		"csv.plus_one":       "Plus 1",
+		"csv.kids":           "Copii",
+		"csv.menu":           "Meniu",
+		"csv.companion_menu": "Meniu Însoțitor",
+		"csv.comment":        "Mesaj",
+		"common.yes":         "Da",
+		"common.no":          "Nu",
+	},
+	English: {
+		// Navigation
+		"nav.dashboard":   "Dashboard",
+		"nav.invitations": "Invitations",
+		"nav.catering":    "Catering",
+		"nav.logout":      "Log out",
+		"nav.audit":       "Audit log",
+		"nav.admins":      "Admins",
+		"nav.sessions":    "Sessions",
+		"nav.security":    "Security",
+		"nav.cards":       "Cards",
+		"nav.checkin":     "Check-in",
+		"nav.switch_lang": "Română",
+
+		// Dashboard
+		"dashboard.title":          "Dashboard - Evite Admin",
+		"dashboard.heading":        "Admin Dashboard",
+		"dashboard.welcome":        "Welcome, %s (%s)",
+		"dashboard.invitations":    "Invitations",
+		"dashboard.sent":           "Sent",
+		"dashboard.opened":         "Opened",
+		"dashboard.responded":      "Responded",
+		"dashboard.out_of":         "of %d (%d%%)",
+		"dashboard.attending":      "Attending",
+		"dashboard.declined":       "Declined",
+		"dashboard.pending":        "No response",
+		"dashboard.headcount":      "Total people",
+		"dashboard.headcount_desc": "%d guests + %d companions + %d kids",
+		"dashboard.menus":          "Adult menus",
+		"dashboard.menu_standard":  "Standard",
+		"dashboard.menu_vegan":     "Vegan",
+		"dashboard.over_time":      "Responses over time",
+		"dashboard.no_responses":   "There are no responses yet.",
+		"dashboard.recent":         "Recent changes",
+		"dashboard.col_when":       "When",
+		"dashboard.new_response":   "New response",
+		"dashboard.updated":        "Updated response",
+		"dashboard.follow_up":      "Follow up: opened without response",
+		"dashboard.follow_help":    "Guests who opened their invitation but haven't responded yet.",
+		"dashboard.no_follow_up":   "No opened invitations without a response.",
+		"dashboard.col_visits":     "Visits",
+		"dashboard.col_last_visit": "Last visit",
+		"dashboard.follow_list":    "Open the list to send reminders",
+		"dashboard.by_tag":         "By group",
+		"dashboard.col_tag":        "Group",
+
+		// Catering report
+		"catering.title":          "Catering - Evite Admin",
+		"catering.heading":        "Catering report",
+		"catering.adult_standard": "Adults - standard menu",
+		"catering.adult_vegan":    "Adults - vegan menu",
+		"catering.children":       "Children",
+		"catering.col_category":   "Category",
+		"catering.col_count":      "People",
+		"catering.col_price":      "Price / person",
+		"catering.col_subtotal":   "Subtotal",
+		"catering.total":          "Estimated total",
+		"catering.breakdown":      "Adults include %d guests and %d companions.",
+		"catering.note":           "Only includes guests who confirmed attendance (latest response).",
+		"catering.print":          "Print / Save as PDF",
+		"catering.generated":      "Generated on %s",
+
+		// Audit log
+		"audit.title":                           "Audit log - Evite Admin",
+		"audit.heading":                         "Audit log",
+		"audit.filter":                          "Filter",
+		"audit.reset":                           "Reset",
+		"audit.all_actions":                     "All actions",
+		"audit.empty":                           "There are no entries for the selected filters.",
+		"audit.limited":                         "Showing the %d most recent entries. The CSV export contains all of them.",
+		"audit.col_when":                        "When",
+		"audit.col_actor":                       "Admin",
+		"audit.col_action":                      "Action",
+		"audit.col_invitation":                  "Invitation ID",
+		"audit.col_target":                      "Guest",
+		"audit.col_changes":                     "Changes",
+		"audit.col_before":                      "Before",
+		"audit.col_after":                       "After",
+		"audit.col_ip":                          "IP",
+		"audit.from":                            "From",
+		"audit.to":                              "To",
+		"audit.action.invitation.create":        "Invitation created",
+		"audit.action.invitation.update":        "Invitation updated",
+		"audit.action.invitation.archive":       "Invitation archived",
+		"audit.action.invitation.restore":       "Invitation restored",
+		"audit.action.invitation.delete":        "Invitation permanently deleted",
+		"audit.action.invitation.mark_sent":     "Marked as sent",
+		"audit.action.invitation.new_link":      "New link generated",
+		"audit.action.invitation.revoke":        "Link revoked",
+		"audit.action.invitation.tag":           "Tag added",
+		"audit.action.invitation.check_in":      "Checked in",
+		"audit.action.invitation.undo_check_in": "Check-in undone",
+		"audit.action.export.csv":               "Invitations CSV export",
+		"audit.action.export.audit_csv":         "Audit log CSV export",
+		"audit.action.export.qr_zip":            "QR codes export",
+		"audit.action.export.cards":             "Cards export",
+		"audit.action.admin.language":           "Language changed",
+		"audit.action.admin.create":             "Admin added",
+		"audit.action.admin.role":               "Role changed",
+		"audit.action.admin.delete":             "Admin removed",
+		"audit.action.admin.session_revoke":     "Session signed out",
+
+		"checkin.title":       "Check-in - Evite Admin",
+		"checkin.heading":     "Check-in",
+		"checkin.help":        "Guests who said they are coming. Search by name and press Check in when they arrive.",
+		"checkin.search":      "Search",
+		"checkin.search_hint": "Guest or companion name",
+		"checkin.arrived":     "People arrived",
+		"checkin.empty":       "No guests found",
+		"checkin.col_party":   "People",
+		"checkin.col_status":  "Status",
+		"checkin.arrived_at":  "Arrived at %s",
+		"checkin.expected":    "Expected",
+		"checkin.check_in":    "Check in",
+		"checkin.undo":        "Undo",
+
+		"sessions.title":         "Sessions - Evite Admin",
+		"sessions.heading":       "Active sessions",
+		"sessions.all_help":      "Devices admins are currently signed in on. You can sign out any session remotely.",
+		"sessions.own_help":      "Devices you are currently signed in on. Sign out any session you don't recognize.",
+		"sessions.empty":         "There are no active sessions.",
+		"sessions.col_admin":     "Admin",
+		"sessions.col_device":    "Device",
+		"sessions.col_ip":        "IP",
+		"sessions.col_last_seen": "Last seen",
+		"sessions.col_signed_in": "Signed in",
+		"sessions.this_device":   "this device",
+		"sessions.sign_out":      "Sign out",
+
+		"security.title":                 "Security - Evite Admin",
+		"security.heading":               "Lockouts and bots",
+		"security.help":                  "Clients that went over the request limits on public pages, and submissions caught by the bot trap.",
+		"security.empty":                 "Nothing has been blocked.",
+		"security.col_kind":              "Kind",
+		"security.col_key":               "IP / token",
+		"security.col_path":              "Page",
+		"security.col_user_agent":        "Browser",
+		"security.kind.rate_limit_ip":    "Too many requests from an IP",
+		"security.kind.rate_limit_token": "Too many unknown invitation links or codes",
+		"security.kind.rate_limit_write": "Too many RSVP submissions for one invitation",
+		"security.kind.honeypot":         "Bot submission ignored",
+
+		// Printable cards
+		"cards.title":       "Cards - Evite Admin",
+		"cards.heading":     "Invitation cards",
+		"cards.help":        "Generate personalized printable cards with the guest's name, the event details and a QR code for their RSVP link. If no invitation is ticked, cards are generated for all of them.",
+		"cards.design":      "Design",
+		"cards.design_card": "Card",
+		"cards.design_long": "Long card",
+		"cards.language":    "Card language",
+		"cards.select_all":  "Select all",
+		"cards.download":    "Download (PNG / ZIP)",
+		"cards.empty":       "There are no invitations with a working link.",
+		"cards.card":        "Printable card",
+
+		// Invitation activity
+		"activity.title":          "Activity - Evite Admin",
+		"activity.heading":        "Activity: %s",
+		"activity.help":           "Every visit of the guest to their invitation page, together with when it was sent and their responses.",
+		"activity.visits":         "%d visits",
+		"activity.created":        "Invitation created",
+		"activity.sent":           "Marked as sent",
+		"activity.previewed":      "Link previewed automatically (WhatsApp, Telegram, ...)",
+		"activity.visit":          "Visit",
+		"activity.form_started":   "started the form",
+		"activity.no_form":        "did not start the form",
+		"activity.response":       "Response submitted",
+		"activity.withdrawn":      "Response withdrawn by the guest",
+		"activity.revoked":        "Link revoked",
+		"activity.device_mobile":  "phone",
+		"activity.device_tablet":  "tablet",
+		"activity.device_desktop": "computer",
+		"activity.device_unknown": "unknown device",
+
+		// Response history
+		"history.title":          "Response history - Evite Admin",
+		"history.heading":        "Response history: %s",
+		"history.help":           "Every response the guest submitted, oldest first, with what changed each time.",
+		"history.link":           "Response history",
+		"history.empty":          "The guest hasn't responded yet.",
+		"history.first":          "First response",
+		"history.no_changes":     "Resubmitted without changes",
+		"history.current":        "Current",
+		"history.withdrawn":      "Withdrawn on %s",
+		"history.yes":            "yes",
+		"history.no":             "no",
+		"history.attending":      "Attending",
+		"history.name":           "Name",
+		"history.plus_one":       "Partner",
+		"history.kids":           "Kids",
+		"history.menu":           "Menu",
+		"history.companion_menu": "Companion menu",
+		"history.comment":        "Message",
+
+		// Archive
+		"archive.title":         "Archive - Evite Admin",
+		"archive.heading":       "Archived invitations",
+		"archive.link":          "Archive",
+		"archive.help":          "Deleted invitations stay here until you restore or permanently delete them. They are left out of stats and exports, and their links no longer work.",
+		"archive.help_purge":    "Deleted invitations stay here for %d days and are then permanently deleted. They are left out of stats and exports, and their links no longer work.",
+		"archive.empty":         "The archive is empty.",
+		"archive.archived_at":   "Archived",
+		"archive.purge_on":      "Permanent deletion",
+		"archive.purge":         "Delete permanently",
+		"archive.confirm_purge": "The invitation and all its responses will be permanently deleted. Continue?",
+		"archive.phone_taken":   "Cannot restore: another invitation already uses this phone number.",
+
+		// Bulk actions
+		"bulk.title":             "Bulk actions - Evite Admin",
+		"bulk.selected":          "%d selected",
+		"bulk.select_all":        "Select all",
+		"bulk.page_selected":     "All %d invitations on this page are selected.",
+		"bulk.select_matching":   "Select all %d matching invitations",
+		"bulk.all_selected":      "All %d matching invitations are selected.",
+		"bulk.clear":             "Clear selection",
+		"bulk.mark_sent":         "Mark as sent",
+		"bulk.send_whatsapp":     "Send via WhatsApp",
+		"bulk.send_sms":          "Send via SMS",
+		"bulk.export":            "Export CSV",
+		"bulk.archive":           "Delete",
+		"bulk.confirm_arch":      "Are you sure you want to delete the selected invitations? You can restore them from the archive.",
+		"bulk.done_mark_sent":    "%d invitations marked as sent.",
+		"bulk.done_archive":      "%d invitations moved to the archive.",
+		"bulk.done_tag":          "%d invitations tagged.",
+		"bulk.tag":               "Add tags",
+		"bulk.tag_ph":            "Tags, comma-separated",
+		"bulk.skipped":           "%d invitations skipped",
+		"bulk.skip_not_found":    "not found",
+		"bulk.skip_archived":     "is archived",
+		"bulk.skip_already_sent": "was already sent",
+		"bulk.skip_revoked":      "link is revoked",
+		"bulk.send_help":         "Open each guest's message and send it, then mark them all as sent.",
+		"bulk.open":              "Open message",
+		"bulk.mark_all_sent":     "Mark all %d as sent",
+
+		// Invitations list search, filters and pages
+		"list.search":             "Search",
+		"list.search_hint":        "Name, phone or name tag",
+		"list.status":             "Filter",
+		"list.tag":                "Group",
+		"list.all":                "All",
+		"list.status_not_sent":    "Not sent",
+		"list.status_not_opened":  "Sent, not opened",
+		"list.status_no_response": "Opened, no response",
+		"list.status_attending":   "Attending",
+		"list.status_declined":    "Declined",
+		"list.status_vegan":       "Vegan menu",
+		"list.status_kids":        "With kids",
+		"list.sort":               "Sort",
+		"list.sort_created":       "Date created",
+		"list.sort_name":          "Name",
+		"list.sort_sent":          "Date sent",
+		"list.sort_responded":     "Date responded",
+		"list.asc":                "Ascending",
+		"list.desc":               "Descending",
+		"list.apply":              "Apply",
+		"list.reset":              "Reset",
+		"list.count":              "%d of %d invitations",
+		"list.no_matches":         "No invitations match the filters.",
+		"list.first":              "← First page",
+		"list.next":               "Next page →",
+
+		// Guest page preview
+		"preview.banner":          "Previewing as the guest: nothing is tracked and the response can't be submitted",
+		"preview.deadline_open":   "Before deadline",
+		"preview.deadline_passed": "After deadline",
+		"preview.response":        "With current response",
+		"preview.no_response":     "Without response",
+		"preview.form":            "Form",
+		"preview.submitted":       "After responding",
+		"preview.exit":            "Back to admin",
+
+		"admins.title":            "Admins - Evite Admin",
+		"admins.heading":          "Admins",
+		"admins.invite_heading":   "Invite a co-host",
+		"admins.invite_help":      "Once added, they can sign in at %s with the Google account for this email.",
+		"admins.invite":           "Invite",
+		"admins.col_email":        "Email",
+		"admins.col_name":         "Name",
+		"admins.col_role":         "Role",
+		"admins.col_invited_by":   "Invited by",
+		"admins.col_last_login":   "Last sign-in",
+		"admins.never":            "Never",
+		"admins.you":              "you",
+		"admins.save_role":        "Save",
+		"admins.remove":           "Remove",
+		"admins.confirm_remove":   "Are you sure you want to remove this admin?",
+		"admins.error_email":      "Enter a valid email address",
+		"admins.error_role":       "Invalid role",
+		"admins.error_exists":     "This address is already an admin",
+		"admins.error_last_owner": "At least one owner must remain",
+		"admins.error_self":       "You can't remove yourself",
+		"role.owner":              "Owner",
+		"role.editor":             "Editor",
+		"role.viewer":             "Viewer",
+		"role.checkin":            "Check-in staff",
+		"role.owner_desc":         "Full access, including deletes, the audit log and admins",
+		"role.editor_desc":        "Creates and edits invitations, sees reports",
+		"role.viewer_desc":        "Read-only access and reports",
+		"role.checkin_desc":       "Only checks guests in at the entrance",
+
+		"notfound.title":   "Page not found",
+		"notfound.heading": "This page does not exist",
+		"notfound.text":    "The link is wrong or incomplete. If you received an invitation, open the link from the message again or enter the invitation code on the home page.",
+		"notfound.home":    "Home page",
+
+		"login.title":              "Sign in - Evite Admin",
+		"login.heading":            "Admin sign-in",
+		"login.with_google":        "Continue with Google",
+		"login.with_oidc":          "Continue with your organization account",
+		"login.or":                 "or",
+		"login.email":              "Email address",
+		"login.send_link":          "Email me a sign-in link",
+		"login.email_help":         "You'll get a link by email that works once, for a few minutes.",
+		"login.sent":               "If the address belongs to an admin, you'll shortly receive an email with a sign-in link.",
+		"login.confirm_heading":    "Confirm sign-in",
+		"login.confirm_text":       "Press the button below to open the admin dashboard.",
+		"login.confirm":            "Sign in",
+		"login.error_email":        "Enter a valid email address",
+		"login.error_rate_limited": "Too many requests. Please try again later.",
+		"login.error_invalid_link": "This sign-in link is invalid, has expired or was already used. Request a new one.",
+		"login.email_subject":      "Your Evite sign-in link",
+		"login.email_body":         "Hello,\n\nUse the link below to sign in to the Evite admin dashboard:\n\n%s\n\nThe link works once and expires in %d minutes.\nIf you didn't request this email, you can ignore it.\n",
+
+		// Invitations list
+		"invitations.title":         "Invitations - Evite Admin",
+		"invitations.heading":       "Invitations",
+		"invitations.download_csv":  "Download CSV",
+		"invitations.download_qr":   "QR codes (ZIP)",
+		"invitations.qr_title":      "Download the QR code of the link",
+		"invitations.new":           "+ New Invitation",
+		"invitations.new_short":     "+ New",
+		"invitations.empty":         "There are no invitations yet.",
+		"invitations.create_first":  "Create the first invitation",
+		"invitations.col_guest":     "Guest",
+		"invitations.col_phone":     "Phone",
+		"invitations.code":          "Invitation code",
+		"invitations.col_status":    "Status",
+		"invitations.col_response":  "Response",
+		"invitations.col_info":      "Info",
+		"invitations.col_actions":   "Actions",
+		"invitations.confirm_del":   "Are you sure you want to delete this invitation? You can restore it from the archive.",
+		"invitations.message_from":  "Message from %s",
+		"invitations.copied":        "Message copied!",
+		"invitations.copy_title":    "Copy invitation message",
+		"invitations.mark_sent":     "Mark as sent",
+		"invitations.new_link":      "Generate a new link (the old link stops working)",
+		"invitations.confirm_new":   "Generate a new link? The old link will stop working and you will need to send the message again.",
+		"invitations.revoke":        "Revoke the invitation link",
+		"invitations.confirm_rev":   "Revoke the link? The guest will not be able to respond until you generate a new link.",
+		"invitations.changed":       "Response changed",
+		"invitations.changed_since": "Highlight responses changed after",
+		"invitations.changed_count": "%d invitations with a changed response",
+		"invitations.view_message":  "View message",
+		"invitations.kids_count":    "%d kids",
+		"invitations.menu":          "Menu",
+		"invitations.companion":     "Companion",
+		"invitations.menu_short":    "M",
+		"invitations.companion_sh":  "C",
+		"invitations.attending":     "✓ Attending",
+		"invitations.not_attending": "✗ Not attending",
+
+		// Status badges
+		"status.sent":            "Sent",
+		"status.not_sent":        "Not sent",
+		"status.opened":          "Opened",
+		"status.responded":       "Responded",
+		"status.revoked":         "Revoked",
+		"status.previewed":       "Previewed",
+		"status.previewed_help":  "The link was only fetched by an automatic preview (WhatsApp, Telegram, ...), not by the guest",
+		"status.sent_short":      "S",
+		"status.opened_short":    "O",
+		"status.responded_short": "R",
+
+		// Common actions
+		"action.message": "Message",
+		"action.history": "Activity",
+		"action.preview": "Preview",
+		"action.close":   "Close",
+		"action.copy":    "Copy",
+		"action.sent":    "Sent",
+		"action.revoke":  "Revoke",
+		"action.renew":   "New link",
+		"action.edit":    "Edit",
+		"action.delete":  "Delete",
+		"action.restore": "Restore",
+		"action.back":    "← Back to list",
+		"action.cancel":  "Cancel",
+
+		// Invitation form
+		"form.new_title":        "New Invitation - Evite Admin",
+		"form.new_heading":      "New Invitation",
+		"form.edit_title":       "Edit Invitation - Evite Admin",
+		"form.edit_heading":     "Edit Invitation",
+		"form.guest_name":       "Guest Name *",
+		"form.guest_name_ph":    "e.g. John Smith",
+		"form.guest_name_help":  "The guest's full name",
+		"form.phone":            "Phone *",
+		"form.phone_ph":         "e.g. +40712345678",
+		"form.phone_help":       "Unique phone number for each guest",
+		"form.tags":             "Groups",
+		"form.tags_ph":          "e.g. godparents, groom's family, colleagues",
+		"form.tags_help":        "Comma-separated tags, used for planning, filters and bulk sending",
+		"form.create":           "Create Invitation",
+		"form.update":           "Update Invitation",
+		"error.form_invalid":    "Failed to process the form",
+		"error.required_fields": "All fields are required",
+		"error.invalid_phone":   "Invalid phone number",
+		"error.phone_exists":    "This phone number already exists",
+		"error.create_failed":   "Failed to create the invitation",
+		"error.update_failed":   "Failed to update. Check that the phone number is not already in use.",
+
+		// CSV export
+		"csv.name":           "Name",
+		"csv.phone":          "Phone",
+		"csv.code":           "Code",
+		"csv.sent":           "Sent",
+		"csv.opened":         "Opened",
+		"csv.responded":      "Responded",
+		"csv.attending":      "Attending",
+		"csv.plus_one":       "Plus 1",
+		"csv.kids":           "Kids",
+		"csv.menu":           "Menu",
+		"csv.companion_menu": "Companion Menu",
+		"csv.comment":        "Message",
+		"common.yes":         "Yes",
+		"common.no":          "No",
+	},
+}
+
+// T returns the admin translation for key in the given language.
+// Falls back to Romanian, then to the key itself, so a missing
+// translation is visible but never breaks a page.
+func T(lang Language, key string) string {
+	if msg, ok := adminMessages[lang][key]; ok {
+		return msg
+	}
+	if msg, ok := adminMessages[Romanian][key]; ok {
+		return msg
+	}
+	return key
+}
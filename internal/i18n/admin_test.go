@@ -0,0 +1,62 @@
+package i18n
+
+import (
+	"testing"
+)
+
+func TestAdminMessagesComplete(t *testing.T) {
+	for lang, messages := range adminMessages {
+		for other, otherMessages := range adminMessages {
+			if lang == other {
+				continue
+			}
+			for key := range messages {
+				if _, ok := otherMessages[key]; !ok {
+					t.Errorf("Key %q exists for %q but is missing for %q", key, lang, other)
+				}
+			}
+		}
+	}
+}
+
+func TestT(t *testing.T) {
+	tests := []struct {
+		name     string
+		lang     Language
+		key      string
+		expected string
+	}{
+		{
+			name:     "Romanian translation",
+			lang:     Romanian,
+			key:      "nav.logout",
+			expected: "Deconectare",
+		},
+		{
+			name:     "English translation",
+			lang:     English,
+			key:      "nav.logout",
+			expected: "Log out",
+		},
+		{
+			name:     "Unknown language falls back to Romanian",
+			lang:     Language("de"),
+			key:      "nav.logout",
+			expected: "Deconectare",
+		},
+		{
+			name:     "Unknown key returns the key",
+			lang:     English,
+			key:      "does.not.exist",
+			expected: "does.not.exist",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if result := T(tt.lang, tt.key); result != tt.expected {
+				t.Errorf("T(%q, %q): expected %q but got %q", tt.lang, tt.key, tt.expected, result)
+			}
+		})
+	}
+}
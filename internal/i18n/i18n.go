@@ -11,25 +11,28 @@ const (
 	English  Language = "en"
 )
 
+// ParseLanguage converts a language code to a supported Language
+func ParseLanguage(code string) (Language, bool) {
+	switch code {
+	case "ro":
+		return Romanian, true
+	case "en":
+		return English, true
+	}
+	return "", false
+}
+
 // GetLanguageFromRequest extracts language from request (query param or cookie)
 func GetLanguageFromRequest(r *http.Request) Language {
 	// Check query parameter first
-	if lang := r.URL.Query().Get("lang"); lang != "" {
-		if lang == "ro" {
-			return Romanian
-		}
-		if lang == "en" {
-			return English
-		}
+	if lang, ok := ParseLanguage(r.URL.Query().Get("lang")); ok {
+		return lang
 	}
 
 	// Check cookie
 	if cookie, err := r.Cookie("lang"); err == nil {
-		if cookie.Value == "ro" {
-			return Romanian
-		}
-		if cookie.Value == "en" {
-			return English
+		if lang, ok := ParseLanguage(cookie.Value); ok {
+			return lang
 		}
 	}
 
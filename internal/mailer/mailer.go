@@ -0,0 +1,66 @@
+package mailer
+
+import (
+	"fmt"
+	"mime"
+	"net"
+	"net/mail"
+	"net/smtp"
+	"strings"
+	"time"
+)
+
+// Config holds the SMTP settings used to send email
+type Config struct {
+	Host     string
+	Port     string
+	Username string
+	Password string
+	From     string
+}
+
+// Enabled reports whether an SMTP server is configured
+func (c Config) Enabled() bool {
+	return c.Host != ""
+}
+
+// Send delivers a plain-text email through the configured SMTP server
+func Send(cfg Config, to, subject, body string) error {
+	if !cfg.Enabled() {
+		return fmt.Errorf("SMTP is not configured")
+	}
+
+	var auth smtp.Auth
+	if cfg.Username != "" {
+		auth = smtp.PlainAuth("", cfg.Username, cfg.Password, cfg.Host)
+	}
+
+	// The envelope sender must be a bare address, while From may include a display name
+	sender, err := mail.ParseAddress(cfg.From)
+	if err != nil {
+		return fmt.Errorf("invalid SMTP_FROM address: %w", err)
+	}
+
+	msg := buildMessage(cfg.From, to, subject, body, time.Now())
+	addr := net.JoinHostPort(cfg.Host, cfg.Port)
+	if err := smtp.SendMail(addr, auth, sender.Address, []string{to}, msg); err != nil {
+		return fmt.Errorf("failed to send email: %w", err)
+	}
+	return nil
+}
+
+// buildMessage formats a plain-text UTF-8 email with CRLF line endings
+func buildMessage(from, to, subject, body string, now time.Time) []byte {
+	var b strings.Builder
+	b.WriteString("From: " + from + "\r\n")
+	b.WriteString("To: " + to + "\r\n")
+	b.WriteString("Subject: " + mime.QEncoding.Encode("utf-8", subject) + "\r\n")
+	b.WriteString("Date: " + now.Format(time.RFC1123Z) + "\r\n")
+	b.WriteString("MIME-Version: 1.0\r\n")
+	b.WriteString("Content-Type: text/plain; charset=utf-8\r\n")
+	b.WriteString("Content-Transfer-Encoding: 8bit\r\n")
+	b.WriteString("\r\n")
+	body = strings.ReplaceAll(body, "\r\n", "\n")
+	b.WriteString(strings.ReplaceAll(body, "\n", "\r\n"))
+	return []byte(b.String())
+}
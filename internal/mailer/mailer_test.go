@@ -0,0 +1,38 @@
+package mailer
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestBuildMessage(t *testing.T) {
+	now := time.Date(2026, 3, 1, 10, 0, 0, 0, time.UTC)
+	msg := string(buildMessage("evite@example.com", "bunica@example.com", "Autentificare în Evite", "Salut,\nLink: https://example.com\n", now))
+
+	tests := []struct {
+		name     string
+		expected string
+	}{
+		{"from header", "From: evite@example.com\r\n"},
+		{"to header", "To: bunica@example.com\r\n"},
+		{"encoded subject", "Subject: =?utf-8?q?Autentificare_=C3=AEn_Evite?=\r\n"},
+		{"date header", "Date: Sun, 01 Mar 2026 10:00:00 +0000\r\n"},
+		{"content type", "Content-Type: text/plain; charset=utf-8\r\n"},
+		{"CRLF body", "\r\n\r\nSalut,\r\nLink: https://example.com\r\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if !strings.Contains(msg, tt.expected) {
+				t.Errorf("Expected message to contain %q but got %q", tt.expected, msg)
+			}
+		})
+	}
+}
+
+func TestSendRequiresConfig(t *testing.T) {
+	if err := Send(Config{}, "a@example.com", "subject", "body"); err == nil {
+		t.Errorf("Expected an error when SMTP is not configured")
+	}
+}
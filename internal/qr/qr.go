@@ -0,0 +1,56 @@
+package qr
+
+import (
+	"fmt"
+	"image"
+	"strings"
+
+	qrcode "github.com/skip2/go-qrcode"
+)
+
+// level is the error correction level used for all codes; medium survives small print defects
+const level = qrcode.Medium
+
+// PNG renders content as a QR code PNG image of size x size pixels
+func PNG(content string, size int) ([]byte, error) {
+	png, err := qrcode.Encode(content, level, size)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode QR code: %w", err)
+	}
+	return png, nil
+}
+
+// Image renders content as a QR code image of size x size pixels without the quiet zone,
+// for drawing onto a light background that already provides the margin
+func Image(content string, size int) (image.Image, error) {
+	code, err := qrcode.New(content, level)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode QR code: %w", err)
+	}
+	code.DisableBorder = true
+	return code.Image(size), nil
+}
+
+// SVG renders content as a scalable QR code, one unit per module, including the quiet zone
+func SVG(content string) ([]byte, error) {
+	code, err := qrcode.New(content, level)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode QR code: %w", err)
+	}
+	bitmap := code.Bitmap()
+
+	var b strings.Builder
+	fmt.Fprintf(&b, `<svg xmlns="http://www.w3.org/2000/svg" viewBox="0 0 %[1]d %[1]d" shape-rendering="crispEdges">`, len(bitmap))
+	fmt.Fprintf(&b, `<rect width="%[1]d" height="%[1]d" fill="#fff"/>`, len(bitmap))
+	b.WriteString(`<path fill="#000" d="`)
+	for y, row := range bitmap {
+		for x, dark := range row {
+			if dark {
+				fmt.Fprintf(&b, "M%d %dh1v1h-1z", x, y)
+			}
+		}
+	}
+	b.WriteString(`"/></svg>`)
+
+	return []byte(b.String()), nil
+}
@@ -0,0 +1,57 @@
+package qr
+
+import (
+	"bytes"
+	"image/png"
+	"strings"
+	"testing"
+)
+
+func TestPNG(t *testing.T) {
+	data, err := PNG("https://example.com/rsvp/0123456789abcdef0123456789abcdef", 256)
+	if err != nil {
+		t.Fatalf("PNG() error = %v", err)
+	}
+
+	img, err := png.Decode(bytes.NewReader(data))
+	if err != nil {
+		t.Fatalf("PNG() did not return a valid PNG: %v", err)
+	}
+	if bounds := img.Bounds(); bounds.Dx() != 256 || bounds.Dy() != 256 {
+		t.Errorf("PNG() size = %dx%d, want 256x256", bounds.Dx(), bounds.Dy())
+	}
+}
+
+func TestSVG(t *testing.T) {
+	data, err := SVG("https://example.com/rsvp/0123456789abcdef0123456789abcdef")
+	if err != nil {
+		t.Fatalf("SVG() error = %v", err)
+	}
+	svg := string(data)
+
+	expected := []string{
+		`<svg xmlns="http://www.w3.org/2000/svg"`,
+		`viewBox="0 0 `,
+		`<path fill="#000" d="M`,
+		`</svg>`,
+	}
+	for _, want := range expected {
+		if !strings.Contains(svg, want) {
+			t.Errorf("Expected SVG to contain %q, got:\n%s", want, svg)
+		}
+	}
+}
+
+func TestSVGDeterministic(t *testing.T) {
+	first, err := SVG("https://example.com/rsvp/abc")
+	if err != nil {
+		t.Fatalf("SVG() error = %v", err)
+	}
+	second, err := SVG("https://example.com/rsvp/abc")
+	if err != nil {
+		t.Fatalf("SVG() error = %v", err)
+	}
+	if !bytes.Equal(first, second) {
+		t.Error("SVG() returned different output for the same content")
+	}
+}
@@ -0,0 +1,95 @@
+package ratelimit
+
+import (
+	"sync"
+	"time"
+)
+
+// Result is the outcome of a request checked by a Limiter
+type Result struct {
+	// Allowed is false while the key is locked out
+	Allowed bool
+	// LockedOut is true only for the request that triggered a lockout
+	LockedOut bool
+	// RetryAfter is how long the key stays locked out
+	RetryAfter time.Duration
+}
+
+// Limiter allows up to Limit requests per key in each fixed window
+// A key that goes over the limit is locked out for the lockout duration
+type Limiter struct {
+	limit   int
+	window  time.Duration
+	lockout time.Duration
+
+	mu        sync.Mutex
+	entries   map[string]*entry
+	lastSweep time.Time
+}
+
+type entry struct {
+	windowStart time.Time
+	count       int
+	lockedUntil time.Time
+}
+
+// New returns a limiter; a limit of 0 or less disables limiting
+func New(limit int, window, lockout time.Duration) *Limiter {
+	return &Limiter{
+		limit:   limit,
+		window:  window,
+		lockout: lockout,
+		entries: make(map[string]*entry),
+	}
+}
+
+// Allow records a request for key at now and reports whether it may proceed
+func (l *Limiter) Allow(key string, now time.Time) Result {
+	if l.limit <= 0 || key == "" {
+		return Result{Allowed: true}
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.sweep(now)
+
+	e, ok := l.entries[key]
+	if !ok {
+		e = &entry{windowStart: now}
+		l.entries[key] = e
+	}
+
+	if now.Before(e.lockedUntil) {
+		return Result{Allowed: false, RetryAfter: e.lockedUntil.Sub(now)}
+	}
+
+	if now.Sub(e.windowStart) >= l.window {
+		e.windowStart = now
+		e.count = 0
+	}
+
+	e.count++
+	if e.count > l.limit {
+		e.lockedUntil = now.Add(l.lockout)
+		e.count = 0
+		return Result{Allowed: false, LockedOut: true, RetryAfter: l.lockout}
+	}
+
+	return Result{Allowed: true}
+}
+
+// sweep drops entries that are neither locked out nor inside their window
+// so the map doesn't grow with every IP or token ever seen
+func (l *Limiter) sweep(now time.Time) {
+	if now.Sub(l.lastSweep) < l.window {
+		return
+	}
+	l.lastSweep = now
+
+	for key, e := range l.entries {
+		if !now.Before(e.lockedUntil) && now.Sub(e.windowStart) >= l.window {
+			delete(l.entries, key)
+		}
+	}
+}
@@ -0,0 +1,82 @@
+package ratelimit
+
+import (
+	"testing"
+	"time"
+)
+
+func TestLimiterAllow(t *testing.T) {
+	start := time.Date(2026, 4, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name          string
+		requests      []time.Duration // offsets from start
+		expectAllowed []bool
+		expectLocked  []bool
+	}{
+		{
+			name:          "Under the limit",
+			requests:      []time.Duration{0, time.Second, 2 * time.Second},
+			expectAllowed: []bool{true, true, true},
+			expectLocked:  []bool{false, false, false},
+		},
+		{
+			name:          "Over the limit locks out",
+			requests:      []time.Duration{0, 1, 2, 3, 4},
+			expectAllowed: []bool{true, true, true, false, false},
+			expectLocked:  []bool{false, false, false, true, false},
+		},
+		{
+			name:          "New window resets the count",
+			requests:      []time.Duration{0, 1, 2, time.Minute, time.Minute + 1},
+			expectAllowed: []bool{true, true, true, true, true},
+			expectLocked:  []bool{false, false, false, false, false},
+		},
+		{
+			name:          "Lockout expires",
+			requests:      []time.Duration{0, 1, 2, 3, 10 * time.Minute, 16 * time.Minute},
+			expectAllowed: []bool{true, true, true, false, false, true},
+			expectLocked:  []bool{false, false, false, true, false, false},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			limiter := New(3, time.Minute, 15*time.Minute)
+			for i, offset := range tt.requests {
+				result := limiter.Allow("192.0.2.1", start.Add(offset))
+				if result.Allowed != tt.expectAllowed[i] {
+					t.Errorf("Request %d: expected allowed=%v but got %v", i, tt.expectAllowed[i], result.Allowed)
+				}
+				if result.LockedOut != tt.expectLocked[i] {
+					t.Errorf("Request %d: expected lockedOut=%v but got %v", i, tt.expectLocked[i], result.LockedOut)
+				}
+			}
+		})
+	}
+}
+
+func TestLimiterKeysAreIndependent(t *testing.T) {
+	limiter := New(1, time.Minute, time.Minute)
+	now := time.Now()
+
+	if !limiter.Allow("a", now).Allowed {
+		t.Errorf("Expected first request for a to be allowed")
+	}
+	if limiter.Allow("a", now).Allowed {
+		t.Errorf("Expected second request for a to be blocked")
+	}
+	if !limiter.Allow("b", now).Allowed {
+		t.Errorf("Expected first request for b to be allowed")
+	}
+}
+
+func TestLimiterDisabled(t *testing.T) {
+	limiter := New(0, time.Minute, time.Minute)
+	now := time.Now()
+	for i := 0; i < 100; i++ {
+		if !limiter.Allow("a", now).Allowed {
+			t.Fatalf("Expected a disabled limiter to allow every request")
+		}
+	}
+}
@@ -0,0 +1,59 @@
+package reports
+
+import (
+	"github.com/AlexTLDR/evite/internal/config"
+	"github.com/AlexTLDR/evite/internal/database"
+)
+
+// CateringLine is a single priced row of the catering report
+type CateringLine struct {
+	// Key is the i18n key of the row label
+	Key       string
+	Count     int
+	UnitPrice float64
+}
+
+// Subtotal returns the cost of the line
+func (l CateringLine) Subtotal() float64 {
+	return float64(l.Count) * l.UnitPrice
+}
+
+// Catering is the per-menu headcount and cost estimate sent to the venue
+type Catering struct {
+	Lines      []CateringLine
+	Guests     int
+	Companions int
+	Currency   string
+}
+
+// NewCatering builds the catering report from menu counts and configured per-head prices
+func NewCatering(counts *database.CateringCounts, cfg *config.Config) *Catering {
+	return &Catering{
+		Lines: []CateringLine{
+			{Key: "catering.adult_standard", Count: counts.GuestStandard + counts.CompanionStandard, UnitPrice: cfg.PriceAdultStandard},
+			{Key: "catering.adult_vegan", Count: counts.GuestVegan + counts.CompanionVegan, UnitPrice: cfg.PriceAdultVegan},
+			{Key: "catering.children", Count: counts.Children, UnitPrice: cfg.PriceChild},
+		},
+		Guests:     counts.GuestStandard + counts.GuestVegan,
+		Companions: counts.CompanionStandard + counts.CompanionVegan,
+		Currency:   cfg.Currency,
+	}
+}
+
+// Headcount returns the total number of people across all lines
+func (c *Catering) Headcount() int {
+	total := 0
+	for _, line := range c.Lines {
+		total += line.Count
+	}
+	return total
+}
+
+// Total returns the estimated total cost
+func (c *Catering) Total() float64 {
+	total := 0.0
+	for _, line := range c.Lines {
+		total += line.Subtotal()
+	}
+	return total
+}
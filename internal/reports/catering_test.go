@@ -0,0 +1,51 @@
+package reports
+
+import (
+	"testing"
+
+	"github.com/AlexTLDR/evite/internal/config"
+	"github.com/AlexTLDR/evite/internal/database"
+)
+
+func TestNewCatering(t *testing.T) {
+	counts := &database.CateringCounts{
+		GuestStandard:     10,
+		GuestVegan:        2,
+		CompanionStandard: 6,
+		CompanionVegan:    1,
+		Children:          4,
+	}
+	cfg := &config.Config{
+		PriceAdultStandard: 100,
+		PriceAdultVegan:    90,
+		PriceChild:         50,
+		Currency:           "EUR",
+	}
+
+	report := NewCatering(counts, cfg)
+
+	expectedCounts := map[string]int{
+		"catering.adult_standard": 16,
+		"catering.adult_vegan":    3,
+		"catering.children":       4,
+	}
+	for _, line := range report.Lines {
+		if line.Count != expectedCounts[line.Key] {
+			t.Errorf("For %q, expected count %d but got %d", line.Key, expectedCounts[line.Key], line.Count)
+		}
+	}
+
+	if report.Guests != 12 {
+		t.Errorf("Expected 12 guests but got %d", report.Guests)
+	}
+	if report.Companions != 7 {
+		t.Errorf("Expected 7 companions but got %d", report.Companions)
+	}
+	if report.Headcount() != 23 {
+		t.Errorf("Expected headcount 23 but got %d", report.Headcount())
+	}
+	// 16*100 + 3*90 + 4*50
+	if report.Total() != 2070 {
+		t.Errorf("Expected total 2070 but got %.2f", report.Total())
+	}
+}
@@ -0,0 +1,89 @@
+package reports
+
+import (
+	"sort"
+	"strconv"
+
+	"github.com/AlexTLDR/evite/internal/database"
+)
+
+// FieldChange is a single field that differs between two consecutive responses
+type FieldChange struct {
+	// Key is the i18n key of the field label
+	Key string
+	Old string
+	New string
+	// Localized is set when Old and New are i18n keys (yes/no values) rather than guest input
+	Localized bool
+}
+
+// HistoryEntry is a response submission together with what changed since the previous one
+// Changes is empty for the first response
+type HistoryEntry struct {
+	Response *database.Response
+	Changes  []FieldChange
+}
+
+// IsFirst reports whether the entry is the invitation's first response
+func (e HistoryEntry) IsFirst() bool {
+	return e.Changes == nil
+}
+
+// NewResponseHistory lists the responses of an invitation oldest first, with the changes of each one
+// compared to the response before it; responses may be passed in any order
+func NewResponseHistory(responses []*database.Response) []HistoryEntry {
+	ordered := make([]*database.Response, len(responses))
+	copy(ordered, responses)
+	sort.SliceStable(ordered, func(i, j int) bool { return submittedBefore(ordered[i], ordered[j]) })
+
+	entries := make([]HistoryEntry, 0, len(ordered))
+	for i, response := range ordered {
+		entry := HistoryEntry{Response: response}
+		if i > 0 {
+			entry.Changes = DiffResponses(ordered[i-1], response)
+		}
+		entries = append(entries, entry)
+	}
+	return entries
+}
+
+// submittedBefore orders responses by submission time, then by ID for responses submitted together
+func submittedBefore(a, b *database.Response) bool {
+	if a.SubmittedAt.Equal(b.SubmittedAt) {
+		return a.ID < b.ID
+	}
+	return a.SubmittedAt.Before(b.SubmittedAt)
+}
+
+// DiffResponses returns the fields that changed between two responses
+// The result is never nil so that a resubmission without changes can be told apart from a first response
+func DiffResponses(previous, current *database.Response) []FieldChange {
+	changes := []FieldChange{}
+	addBool := func(key string, old, new bool) {
+		if old != new {
+			changes = append(changes, FieldChange{Key: key, Old: yesNo(old), New: yesNo(new), Localized: true})
+		}
+	}
+	add := func(key, old, new string) {
+		if old != new {
+			changes = append(changes, FieldChange{Key: key, Old: old, New: new})
+		}
+	}
+
+	addBool("history.attending", previous.Attending, current.Attending)
+	add("history.name", previous.GuestNameTag, current.GuestNameTag)
+	addBool("history.plus_one", previous.PlusOne, current.PlusOne)
+	add("history.kids", strconv.Itoa(previous.KidsCount), strconv.Itoa(current.KidsCount))
+	add("history.menu", previous.MenuPreference.String, current.MenuPreference.String)
+	add("history.companion_menu", previous.CompanionMenuPreference.String, current.CompanionMenuPreference.String)
+	add("history.comment", previous.Comment.String, current.Comment.String)
+	return changes
+}
+
+// yesNo returns the i18n key of a yes/no value
+func yesNo(value bool) string {
+	if value {
+		return "history.yes"
+	}
+	return "history.no"
+}
@@ -0,0 +1,104 @@
+package reports
+
+import (
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/AlexTLDR/evite/internal/database"
+)
+
+func TestDiffResponses(t *testing.T) {
+	previous := &database.Response{
+		Attending:      true,
+		GuestNameTag:   "Ana Pop",
+		KidsCount:      2,
+		MenuPreference: sql.NullString{String: "standard", Valid: true},
+	}
+
+	tests := []struct {
+		name     string
+		current  database.Response
+		expected []FieldChange
+	}{
+		{
+			name:     "No changes",
+			current:  *previous,
+			expected: []FieldChange{},
+		},
+		{
+			name: "Kids and menu",
+			current: database.Response{
+				Attending:      true,
+				GuestNameTag:   "Ana Pop",
+				KidsCount:      1,
+				MenuPreference: sql.NullString{String: "vegan", Valid: true},
+			},
+			expected: []FieldChange{
+				{Key: "history.kids", Old: "2", New: "1"},
+				{Key: "history.menu", Old: "standard", New: "vegan"},
+			},
+		},
+		{
+			name: "Declined",
+			current: database.Response{
+				Attending:    false,
+				GuestNameTag: "Ana Pop",
+				Comment:      sql.NullString{String: "Sorry!", Valid: true},
+			},
+			expected: []FieldChange{
+				{Key: "history.attending", Old: "history.yes", New: "history.no", Localized: true},
+				{Key: "history.kids", Old: "2", New: "0"},
+				{Key: "history.menu", Old: "standard", New: ""},
+				{Key: "history.comment", Old: "", New: "Sorry!"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			changes := DiffResponses(previous, &tt.current)
+			if changes == nil {
+				t.Fatal("Expected a non-nil list of changes")
+			}
+			if len(changes) != len(tt.expected) {
+				t.Fatalf("Expected %d changes but got %d: %v", len(tt.expected), len(changes), changes)
+			}
+			for i, change := range changes {
+				if change != tt.expected[i] {
+					t.Errorf("Change %d: expected %+v but got %+v", i, tt.expected[i], change)
+				}
+			}
+		})
+	}
+}
+
+func TestNewResponseHistory(t *testing.T) {
+	day := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	// Newest first, as returned by GetAllResponsesByInvitationID
+	responses := []*database.Response{
+		{ID: 3, Attending: false, SubmittedAt: day.Add(48 * time.Hour)},
+		{ID: 2, Attending: true, KidsCount: 1, SubmittedAt: day.Add(24 * time.Hour)},
+		{ID: 1, Attending: true, KidsCount: 2, SubmittedAt: day},
+	}
+
+	history := NewResponseHistory(responses)
+
+	if len(history) != 3 {
+		t.Fatalf("Expected 3 entries but got %d", len(history))
+	}
+	for i, id := range []int64{1, 2, 3} {
+		if history[i].Response.ID != id {
+			t.Errorf("Entry %d: expected response %d but got %d", i, id, history[i].Response.ID)
+		}
+	}
+	if !history[0].IsFirst() {
+		t.Error("Expected the oldest response to be the first one")
+	}
+	if history[1].IsFirst() || len(history[1].Changes) != 1 {
+		t.Errorf("Expected one change for the second response but got %v", history[1].Changes)
+	}
+	if responses[0].ID != 3 {
+		t.Error("Expected the input slice to be left unchanged")
+	}
+}
@@ -2,10 +2,15 @@ package server
 
 import (
 	"context"
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
 	"encoding/json"
+	"fmt"
 	"io"
 	"net/http"
 
+	"github.com/AlexTLDR/evite/internal/auth"
 	"golang.org/x/oauth2"
 	"golang.org/x/oauth2/google"
 )
@@ -23,13 +28,69 @@ func (s *Server) getGoogleOAuthConfig() *oauth2.Config {
 	}
 }
 
+// oauthStateMaxAge is how long a started provider login can be completed (seconds)
+const oauthStateMaxAge = 10 * 60
+
+// beginOAuthLogin stores a random state and PKCE verifier for a provider login
+// in a short-lived session and returns them
+func (s *Server) beginOAuthLogin(w http.ResponseWriter, r *http.Request) (state, verifier string, err error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", "", fmt.Errorf("failed to generate OAuth state: %w", err)
+	}
+	state = base64.RawURLEncoding.EncodeToString(b)
+	verifier = oauth2.GenerateVerifier()
+
+	session, _ := s.sessionStore.Get(r, "oauth-state")
+	session.Values["state"] = state
+	session.Values["verifier"] = verifier
+	session.Options.MaxAge = oauthStateMaxAge
+	if err := session.Save(r, w); err != nil {
+		return "", "", fmt.Errorf("failed to save OAuth state: %w", err)
+	}
+
+	return state, verifier, nil
+}
+
+// finishOAuthLogin checks the state returned by the provider against the one
+// stored by beginOAuthLogin and returns the PKCE verifier
+// The stored state is cleared so it can only be used once
+func (s *Server) finishOAuthLogin(w http.ResponseWriter, r *http.Request) (string, bool) {
+	session, _ := s.sessionStore.Get(r, "oauth-state")
+	state, _ := session.Values["state"].(string)
+	verifier, _ := session.Values["verifier"].(string)
+
+	session.Options.MaxAge = -1
+	if err := session.Save(r, w); err != nil {
+		fmt.Printf("Warning: failed to clear OAuth state: %v\n", err)
+	}
+
+	returned := r.URL.Query().Get("state")
+	if state == "" || verifier == "" || subtle.ConstantTimeCompare([]byte(state), []byte(returned)) != 1 {
+		return "", false
+	}
+	return verifier, true
+}
+
 func (s *Server) handleGoogleLogin(w http.ResponseWriter, r *http.Request) {
+	state, verifier, err := s.beginOAuthLogin(w, r)
+	if err != nil {
+		http.Error(w, "Failed to start login", http.StatusInternalServerError)
+		return
+	}
+
 	oauthConfig := s.getGoogleOAuthConfig()
-	url := oauthConfig.AuthCodeURL("state", oauth2.AccessTypeOffline)
+	url := oauthConfig.AuthCodeURL(state, oauth2.AccessTypeOffline, oauth2.S256ChallengeOption(verifier))
 	http.Redirect(w, r, url, http.StatusTemporaryRedirect)
 }
 
 func (s *Server) handleGoogleCallback(w http.ResponseWriter, r *http.Request) {
+	verifier, ok := s.finishOAuthLogin(w, r)
+	if !ok {
+		http.Error(w, "Invalid login state, please try again", http.StatusBadRequest)
+		return
+	}
+
 	code := r.URL.Query().Get("code")
 	if code == "" {
 		http.Error(w, "Code not found", http.StatusBadRequest)
@@ -37,7 +98,7 @@ func (s *Server) handleGoogleCallback(w http.ResponseWriter, r *http.Request) {
 	}
 
 	oauthConfig := s.getGoogleOAuthConfig()
-	token, err := oauthConfig.Exchange(context.Background(), code)
+	token, err := oauthConfig.Exchange(context.Background(), code, oauth2.VerifierOption(verifier))
 	if err != nil {
 		http.Error(w, "Failed to exchange token", http.StatusInternalServerError)
 		return
@@ -67,44 +128,109 @@ func (s *Server) handleGoogleCallback(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// Check if email is in the whitelist
-	if !s.isAdminEmail(userInfo.Email) {
-		http.Error(w, "Unauthorized: Your email is not whitelisted", http.StatusUnauthorized)
+	s.completeLogin(w, r, userInfo.Email, userInfo.Name)
+}
+
+// getOIDCProvider returns the configured OpenID Connect provider
+// Discovery runs on first use and is retried until it succeeds
+func (s *Server) getOIDCProvider(ctx context.Context) (*auth.OIDCProvider, error) {
+	s.oidcMu.Lock()
+	defer s.oidcMu.Unlock()
+
+	if s.oidcProvider != nil {
+		return s.oidcProvider, nil
+	}
+
+	provider, err := auth.NewOIDCProvider(ctx, auth.OIDCConfig{
+		IssuerURL:    s.config.OIDCIssuerURL,
+		ClientID:     s.config.OIDCClientID,
+		ClientSecret: s.config.OIDCClientSecret,
+		RedirectURL:  s.config.OIDCRedirectURL,
+		Scopes:       s.config.OIDCScopes,
+		EmailClaim:   s.config.OIDCEmailClaim,
+		NameClaim:    s.config.OIDCNameClaim,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.oidcProvider = provider
+	return provider, nil
+}
+
+func (s *Server) handleOIDCLogin(w http.ResponseWriter, r *http.Request) {
+	provider, err := s.getOIDCProvider(r.Context())
+	if err != nil {
+		fmt.Printf("Warning: OIDC provider unavailable: %v\n", err)
+		http.Error(w, "Login provider unavailable", http.StatusServiceUnavailable)
 		return
 	}
 
-	// Save to session
-	session, _ := s.sessionStore.Get(r, "auth-session")
-	session.Values["email"] = userInfo.Email
-	session.Values["name"] = userInfo.Name
-	if err := session.Save(r, w); err != nil {
-		http.Error(w, "Failed to save session", http.StatusInternalServerError)
+	state, verifier, err := s.beginOAuthLogin(w, r)
+	if err != nil {
+		http.Error(w, "Failed to start login", http.StatusInternalServerError)
 		return
 	}
 
-	http.Redirect(w, r, "/admin", http.StatusSeeOther)
+	http.Redirect(w, r, provider.AuthCodeURL(state, oauth2.S256ChallengeOption(verifier)), http.StatusTemporaryRedirect)
 }
 
-func (s *Server) handleLogout(w http.ResponseWriter, r *http.Request) {
-	session, _ := s.sessionStore.Get(r, "auth-session")
-	session.Values["email"] = ""
-	session.Values["name"] = ""
-	session.Options.MaxAge = -1
-	if err := session.Save(r, w); err != nil {
-		http.Error(w, "Failed to clear session", http.StatusInternalServerError)
+func (s *Server) handleOIDCCallback(w http.ResponseWriter, r *http.Request) {
+	verifier, ok := s.finishOAuthLogin(w, r)
+	if !ok {
+		http.Error(w, "Invalid login state, please try again", http.StatusBadRequest)
 		return
 	}
 
-	http.Redirect(w, r, "/", http.StatusSeeOther)
+	code := r.URL.Query().Get("code")
+	if code == "" {
+		http.Error(w, "Code not found", http.StatusBadRequest)
+		return
+	}
+
+	provider, err := s.getOIDCProvider(r.Context())
+	if err != nil {
+		fmt.Printf("Warning: OIDC provider unavailable: %v\n", err)
+		http.Error(w, "Login provider unavailable", http.StatusServiceUnavailable)
+		return
+	}
+
+	identity, err := provider.Exchange(r.Context(), code, oauth2.VerifierOption(verifier))
+	if err != nil {
+		fmt.Printf("Warning: OIDC login failed: %v\n", err)
+		http.Error(w, "Failed to verify login", http.StatusUnauthorized)
+		return
+	}
+
+	s.completeLogin(w, r, identity.Email, identity.Name)
 }
 
-func (s *Server) getCurrentUser(r *http.Request) (email, name string) {
-	session, _ := s.sessionStore.Get(r, "auth-session")
-	if e, ok := session.Values["email"].(string); ok {
-		email = e
+// completeLogin checks that the signed-in user is an admin and starts their session
+func (s *Server) completeLogin(w http.ResponseWriter, r *http.Request, email, name string) {
+	// Check if email belongs to an admin
+	role, err := s.adminRole(email)
+	if err != nil {
+		http.Error(w, "Failed to check admin access", http.StatusInternalServerError)
+		return
 	}
-	if n, ok := session.Values["name"].(string); ok {
-		name = n
+	if role == "" {
+		http.Error(w, "Unauthorized: Your email is not an admin", http.StatusUnauthorized)
+		return
 	}
-	return
+
+	if err := s.db.RecordAdminLogin(email, name); err != nil {
+		fmt.Printf("Warning: failed to record admin login: %v\n", err)
+	}
+
+	if err := s.createSession(w, r, email, name); err != nil {
+		http.Error(w, "Failed to save session", http.StatusInternalServerError)
+		return
+	}
+
+	http.Redirect(w, r, "/admin", http.StatusSeeOther)
+}
+
+func (s *Server) handleLogout(w http.ResponseWriter, r *http.Request) {
+	s.destroySession(w, r)
+	http.Redirect(w, r, "/", http.StatusSeeOther)
 }
@@ -0,0 +1,202 @@
+package server
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"net/mail"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/AlexTLDR/evite/internal/auth"
+	"github.com/AlexTLDR/evite/internal/config"
+	"github.com/AlexTLDR/evite/internal/i18n"
+	"github.com/AlexTLDR/evite/internal/mailer"
+	"github.com/AlexTLDR/evite/internal/utils"
+	"github.com/AlexTLDR/evite/templates"
+)
+
+const (
+	// loginLinkTTL is how long an emailed login link stays valid
+	loginLinkTTL = 15 * time.Minute
+	// loginLinkWindow is the period the login link rate limits apply to
+	loginLinkWindow = time.Hour
+	// maxLoginLinksPerEmail limits how many links one address can request per window
+	maxLoginLinksPerEmail = 3
+	// maxLoginLinksPerIP limits how many links one client can request per window
+	maxLoginLinksPerIP = 10
+	// loginLinkRetention is how long login requests are kept for rate limiting
+	loginLinkRetention = 24 * time.Hour
+)
+
+func (s *Server) getMailerConfig() mailer.Config {
+	return mailer.Config{
+		Host:     s.config.SMTPHost,
+		Port:     s.config.SMTPPort,
+		Username: s.config.SMTPUsername,
+		Password: s.config.SMTPPassword,
+		From:     s.config.SMTPFrom,
+	}
+}
+
+// renderLogin renders the login page with an optional error message
+func (s *Server) renderLogin(w http.ResponseWriter, r *http.Request, lang i18n.Language, sent bool, errorMsg string, status int) {
+	themes := config.GetThemes()
+
+	// Render first so that a render error can still be answered with its own status
+	var buf bytes.Buffer
+	if err := templates.Login(string(lang), s.config.AuthProvider, s.getMailerConfig().Enabled(), sent, errorMsg, themes.Light, themes.Dark).Render(r.Context(), &buf); err != nil {
+		http.Error(w, "Failed to render page", http.StatusInternalServerError)
+		return
+	}
+	w.WriteHeader(status)
+	buf.WriteTo(w)
+}
+
+// handleLogin shows the login options, or sends the admin straight to the
+// login provider selected by AUTH_PROVIDER when email login is disabled
+func (s *Server) handleLogin(w http.ResponseWriter, r *http.Request) {
+	if !s.getMailerConfig().Enabled() {
+		http.Redirect(w, r, "/auth/"+s.config.AuthProvider, http.StatusSeeOther)
+		return
+	}
+
+	s.renderLogin(w, r, i18n.GetLanguageFromRequest(r), false, "", http.StatusOK)
+}
+
+// handleEmailLogin emails a one-time login link to an admin address
+// The response is the same whether or not the address is an admin
+func (s *Server) handleEmailLogin(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost || !s.getMailerConfig().Enabled() {
+		http.Redirect(w, r, "/auth/login", http.StatusSeeOther)
+		return
+	}
+
+	lang := i18n.GetLanguageFromRequest(r)
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, "Invalid form", http.StatusBadRequest)
+		return
+	}
+
+	email := strings.ToLower(strings.TrimSpace(r.FormValue("email")))
+	if addr, err := mail.ParseAddress(email); err != nil || addr.Address != email {
+		s.renderLogin(w, r, lang, false, i18n.T(lang, "login.error_email"), http.StatusBadRequest)
+		return
+	}
+
+	ip := utils.ClientIP(r)
+	byEmail, byIP, err := s.db.CountAdminLoginLinks(email, ip, time.Now().Add(-loginLinkWindow))
+	if err != nil {
+		http.Error(w, "Failed to check login requests", http.StatusInternalServerError)
+		return
+	}
+	if byEmail >= maxLoginLinksPerEmail || byIP >= maxLoginLinksPerIP {
+		s.renderLogin(w, r, lang, false, i18n.T(lang, "login.error_rate_limited"), http.StatusTooManyRequests)
+		return
+	}
+
+	admin, err := s.db.GetAdminByEmail(email)
+	if err != nil {
+		http.Error(w, "Failed to check admin access", http.StatusInternalServerError)
+		return
+	}
+
+	// Both paths store a login request and nothing else before answering, so that the response time
+	// doesn't reveal admin addresses; the email is sent in the background
+	expiresAt := time.Now().Add(loginLinkTTL)
+	if admin == nil {
+		// Record the request anyway so it counts towards the rate limits
+		if err := s.db.CreateAdminLoginLink(email, "", ip, expiresAt); err != nil {
+			http.Error(w, "Failed to record login request", http.StatusInternalServerError)
+			return
+		}
+	} else {
+		link, err := s.createLoginLink(lang, email, ip, expiresAt)
+		if err != nil {
+			http.Error(w, "Failed to record login request", http.StatusInternalServerError)
+			return
+		}
+		go s.sendLoginLink(lang, email, link)
+	}
+
+	if err := s.db.DeleteAdminLoginLinksBefore(time.Now().Add(-loginLinkRetention)); err != nil {
+		fmt.Printf("Warning: failed to delete old login links: %v\n", err)
+	}
+
+	s.renderLogin(w, r, lang, true, "", http.StatusOK)
+}
+
+// createLoginLink stores a new login link for an admin and returns its URL
+func (s *Server) createLoginLink(lang i18n.Language, email, ip string, expiresAt time.Time) (string, error) {
+	token, err := auth.NewLoginToken()
+	if err != nil {
+		return "", err
+	}
+
+	if err := s.db.CreateAdminLoginLink(email, auth.HashLoginToken(token), ip, expiresAt); err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("%s/auth/email/verify?lang=%s&token=%s",
+		s.config.BaseURL, lang, url.QueryEscape(auth.SignLoginToken(s.config.SessionSecret, token))), nil
+}
+
+// sendLoginLink emails a login link to an admin
+// Errors are only logged: the admin has already been shown the usual confirmation
+func (s *Server) sendLoginLink(lang i18n.Language, email, link string) {
+	body := fmt.Sprintf(i18n.T(lang, "login.email_body"), link, int(loginLinkTTL.Minutes()))
+	if err := mailer.Send(s.getMailerConfig(), email, i18n.T(lang, "login.email_subject"), body); err != nil {
+		fmt.Printf("Warning: failed to send login link: %v\n", err)
+	}
+}
+
+// handleEmailVerify signs an admin in with an emailed login link
+// GET only shows a confirmation button, so link scanners that open the link
+// don't use it up; the link is consumed by the POST
+func (s *Server) handleEmailVerify(w http.ResponseWriter, r *http.Request) {
+	lang := i18n.GetLanguageFromRequest(r)
+
+	if r.Method != http.MethodPost {
+		signed := r.URL.Query().Get("token")
+		if _, ok := auth.VerifyLoginToken(s.config.SessionSecret, signed); !ok {
+			s.renderLogin(w, r, lang, false, i18n.T(lang, "login.error_invalid_link"), http.StatusBadRequest)
+			return
+		}
+
+		themes := config.GetThemes()
+		if err := templates.LoginConfirm(string(lang), signed, themes.Light, themes.Dark).Render(r.Context(), w); err != nil {
+			http.Error(w, "Failed to render page", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, "Invalid form", http.StatusBadRequest)
+		return
+	}
+
+	token, ok := auth.VerifyLoginToken(s.config.SessionSecret, r.FormValue("token"))
+	if !ok {
+		s.renderLogin(w, r, lang, false, i18n.T(lang, "login.error_invalid_link"), http.StatusBadRequest)
+		return
+	}
+
+	email, err := s.db.ConsumeAdminLoginLink(auth.HashLoginToken(token))
+	if err != nil {
+		http.Error(w, "Failed to verify login link", http.StatusInternalServerError)
+		return
+	}
+	if email == "" {
+		s.renderLogin(w, r, lang, false, i18n.T(lang, "login.error_invalid_link"), http.StatusBadRequest)
+		return
+	}
+
+	// Keep the name from the admin's last provider login, if any
+	name := email
+	if admin, err := s.db.GetAdminByEmail(email); err == nil && admin != nil && admin.Name.Valid && admin.Name.String != "" {
+		name = admin.Name.String
+	}
+
+	s.completeLogin(w, r, email, name)
+}
@@ -0,0 +1,136 @@
+package handlers
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/AlexTLDR/evite/internal/calendar"
+	"github.com/AlexTLDR/evite/internal/config"
+	"github.com/AlexTLDR/evite/internal/database"
+	"github.com/AlexTLDR/evite/internal/i18n"
+	"github.com/AlexTLDR/evite/internal/reports"
+	"github.com/AlexTLDR/evite/templates"
+)
+
+// HandleAdminInvitationActivity shows the activity timeline of an invitation (visits, sending and responses)
+// URL format: /admin/invitations/activity/{id}
+func HandleAdminInvitationActivity(s AdminServer) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		_, userName := s.GetCurrentUser(r)
+		lang := adminLanguage(s, r)
+		themes := config.GetThemes()
+
+		id, err := parseID(r.URL.Path[len("/admin/invitations/activity/"):])
+		if err != nil {
+			http.Error(w, "Invalid invitation ID", http.StatusBadRequest)
+			return
+		}
+
+		invitation, err := s.GetDB().GetInvitationByID(id)
+		if err != nil {
+			http.Error(w, "Invitation not found", http.StatusNotFound)
+			return
+		}
+
+		events, err := s.GetDB().GetInvitationActivity(invitation)
+		if err != nil {
+			http.Error(w, "Failed to load activity", http.StatusInternalServerError)
+			return
+		}
+
+		visits := 0
+		for _, event := range events {
+			if event.Visit != nil {
+				visits++
+			}
+		}
+
+		if err := templates.AdminInvitationActivity(string(lang), userName, invitation, events, visits, themes.Light, themes.Dark).Render(r.Context(), w); err != nil {
+			http.Error(w, "Failed to render page", http.StatusInternalServerError)
+		}
+	}
+}
+
+// HandleAdminResponseHistory lists every response of an invitation with what changed between them
+// URL format: /admin/invitations/history/{id}
+func HandleAdminResponseHistory(s AdminServer) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		_, userName := s.GetCurrentUser(r)
+		lang := adminLanguage(s, r)
+		themes := config.GetThemes()
+
+		id, err := parseID(r.URL.Path[len("/admin/invitations/history/"):])
+		if err != nil {
+			http.Error(w, "Invalid invitation ID", http.StatusBadRequest)
+			return
+		}
+
+		invitation, err := s.GetDB().GetInvitationByID(id)
+		if err != nil {
+			http.Error(w, "Invitation not found", http.StatusNotFound)
+			return
+		}
+
+		responses, err := s.GetDB().GetAllResponsesByInvitationID(id)
+		if err != nil {
+			http.Error(w, "Failed to load responses", http.StatusInternalServerError)
+			return
+		}
+
+		history := reports.NewResponseHistory(responses)
+		if err := templates.AdminResponseHistory(string(lang), userName, invitation, history, themes.Light, themes.Dark).Render(r.Context(), w); err != nil {
+			http.Error(w, "Failed to render page", http.StatusInternalServerError)
+		}
+	}
+}
+
+// HandleAdminPreviewInvitation renders an invitation's guest page for an admin
+// Unlike the guest link it records no visit, doesn't mark the invitation as sent or opened and can't submit a response
+// URL format: /admin/invitations/preview/{id}?lang=ro|en&deadline=passed&submitted=true&attending=yes&response=none
+func HandleAdminPreviewInvitation(s AdminServer) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		cfg := s.GetConfig()
+		themes := config.GetThemes()
+
+		id, err := parseID(r.URL.Path[len("/admin/invitations/preview/"):])
+		if err != nil {
+			http.Error(w, "Invalid invitation ID", http.StatusBadRequest)
+			return
+		}
+
+		invitation, err := s.GetDB().GetInvitationByID(id)
+		if err != nil || invitation.DeletedAt.Valid {
+			http.Error(w, "Invitation not found", http.StatusNotFound)
+			return
+		}
+
+		// The guest language is chosen explicitly so that previewing doesn't depend on the admin's language cookie
+		lang, ok := i18n.ParseLanguage(r.URL.Query().Get("lang"))
+		if !ok {
+			lang = i18n.Romanian
+		}
+
+		preview := &templates.GuestPreview{
+			InvitationID:   invitation.ID,
+			DeadlinePassed: r.URL.Query().Get("deadline") == "passed",
+			Submitted:      r.URL.Query().Get("submitted") == "true",
+			HideResponse:   r.URL.Query().Get("response") == "none",
+		}
+
+		var response *database.Response
+		if !preview.HideResponse {
+			response = latestResponse(s, invitation)
+		}
+
+		var links *calendar.Links
+		if preview.Submitted && r.URL.Query().Get("attending") == "yes" {
+			links = calendarLinks(cfg, invitation.GuestName, invitation.Token, lang)
+		}
+
+		linkStatus := invitationLinkStatus(cfg, invitation, time.Now())
+		if err := templates.Home(string(lang), themes.Light, themes.Dark, invitation, response, 0, linkStatus, false,
+			preview.DeadlinePassed, formatDeadline(cfg.RSVPDeadline, lang), links, preview).Render(r.Context(), w); err != nil {
+			http.Error(w, "Failed to render page", http.StatusInternalServerError)
+		}
+	}
+}
@@ -1,9 +1,12 @@
 package handlers
 
 import (
+	"database/sql"
 	"fmt"
 	"net/http"
+	"net/url"
 	"strings"
+	"time"
 
 	"github.com/AlexTLDR/evite/internal/config"
 	"github.com/AlexTLDR/evite/internal/database"
@@ -16,6 +19,7 @@ import (
 type AdminServer interface {
 	Server
 	GetCurrentUser(r *http.Request) (string, string)
+	GetCurrentSession(r *http.Request) *database.AdminSession
 }
 
 // parseID parses an ID string and returns an error if invalid
@@ -53,54 +57,221 @@ func parseFormID(r *http.Request, w http.ResponseWriter) (int64, bool) {
 	return id, true
 }
 
-// HandleAdminDashboard renders the admin dashboard
+// adminLanguage returns the admin UI language stored for the current admin
+// Defaults to Romanian when no preference has been saved
+func adminLanguage(s AdminServer, r *http.Request) i18n.Language {
+	email, _ := s.GetCurrentUser(r)
+	stored, err := s.GetDB().GetAdminLanguage(email)
+	if err != nil {
+		// Log but don't fail - fall back to the default language
+		fmt.Printf("Warning: failed to load admin language: %v\n", err)
+	}
+	if lang, ok := i18n.ParseLanguage(stored); ok {
+		return lang
+	}
+	return i18n.Romanian
+}
+
+// adminReferer returns the admin page the request came from, or the dashboard
+// Only local /admin paths are accepted so the referer can't redirect off-site
+func adminReferer(r *http.Request) string {
+	ref, err := url.Parse(r.Referer())
+	if err != nil || !strings.HasPrefix(ref.Path, "/admin") {
+		return "/admin"
+	}
+	if ref.RawQuery != "" {
+		return ref.Path + "?" + ref.RawQuery
+	}
+	return ref.Path
+}
+
+// HandleAdminSetLanguage stores the admin UI language preference for the current admin
+func HandleAdminSetLanguage(s AdminServer) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			http.Redirect(w, r, "/admin", http.StatusSeeOther)
+			return
+		}
+
+		if err := r.ParseForm(); err != nil {
+			http.Error(w, "Invalid form", http.StatusBadRequest)
+			return
+		}
+
+		lang, ok := i18n.ParseLanguage(r.FormValue("lang"))
+		if !ok {
+			http.Error(w, "Invalid language", http.StatusBadRequest)
+			return
+		}
+
+		email, _ := s.GetCurrentUser(r)
+		previous := adminLanguage(s, r)
+		if err := s.GetDB().SetAdminLanguage(email, string(lang)); err != nil {
+			http.Error(w, "Failed to save language", http.StatusInternalServerError)
+			return
+		}
+
+		recordAudit(s, r, database.AuditLanguageChange, 0, "", previous, lang)
+
+		http.Redirect(w, r, adminReferer(r), http.StatusSeeOther)
+	}
+}
+
+// recentResponsesLimit is the number of recent changes shown on the dashboard
+const recentResponsesLimit = 10
+
+// HandleAdminDashboard renders the admin dashboard with attendance statistics
 func HandleAdminDashboard(s AdminServer) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
-		email, name := s.GetCurrentUser(r)
-		w.Header().Set("Content-Type", "text/html; charset=utf-8")
-		_, _ = fmt.Fprintf(w, `
-			<!DOCTYPE html>
-			<html>
-			<head>
-				<title>Admin Dashboard</title>
-			</head>
-			<body>
-				<h1>Admin Dashboard</h1>
-				<p>Welcome, %s (%s)</p>
-				<nav>
-					<a href="/admin/invitations">Invitations</a> |
-					<a href="/auth/logout">Logout</a>
-				</nav>
-			</body>
-			</html>
-		`, name, email)
+		email, userName := s.GetCurrentUser(r)
+		lang := adminLanguage(s, r)
+
+		stats, err := s.GetDB().GetDashboardStats()
+		if err != nil {
+			http.Error(w, "Failed to load statistics", http.StatusInternalServerError)
+			return
+		}
+
+		daily, err := s.GetDB().GetResponsesPerDay()
+		if err != nil {
+			http.Error(w, "Failed to load statistics", http.StatusInternalServerError)
+			return
+		}
+
+		recent, err := s.GetDB().GetRecentResponses(recentResponsesLimit)
+		if err != nil {
+			http.Error(w, "Failed to load recent responses", http.StatusInternalServerError)
+			return
+		}
+
+		followUps, err := s.GetDB().GetOpenedWithoutResponse()
+		if err != nil {
+			http.Error(w, "Failed to load invitations to follow up", http.StatusInternalServerError)
+			return
+		}
+
+		tagStats, err := s.GetDB().GetDashboardStatsByTag()
+		if err != nil {
+			http.Error(w, "Failed to load statistics", http.StatusInternalServerError)
+			return
+		}
+
+		themes := config.GetThemes()
+		if err := templates.AdminDashboard(string(lang), userName, email, stats, daily, recent, followUps, tagStats, themes.Light, themes.Dark).Render(r.Context(), w); err != nil {
+			http.Error(w, "Failed to render page", http.StatusInternalServerError)
+		}
+	}
+}
+
+// invitationsPageSize is the number of invitations shown per page of the list
+const invitationsPageSize = 50
+
+// parseInvitationFilter reads the search, filters, sort order and page of the invitations list from the URL
+// It also returns the normalized query, so links in the list keep the same filters
+func parseInvitationFilter(r *http.Request) (database.InvitationFilter, url.Values) {
+	query := r.URL.Query()
+	filter := database.InvitationFilter{
+		Search: strings.TrimSpace(query.Get("q")),
+		Status: query.Get("status"),
+		Tag:    strings.TrimSpace(query.Get("tag")),
+		Sort:   query.Get("sort"),
+		Limit:  invitationsPageSize,
+	}
+
+	if !database.IsInvitationSort(filter.Sort) {
+		filter.Sort = database.InvitationSortCreated
+	}
+	// Names read best A-Z, dates newest first
+	switch query.Get("dir") {
+	case "asc":
+		filter.Asc = true
+	case "desc":
+	default:
+		filter.Asc = filter.Sort == database.InvitationSortName
 	}
+	if after, err := database.ParseInvitationCursor(query.Get("after")); err == nil {
+		filter.After = after
+	}
+
+	filters := url.Values{}
+	for key, value := range map[string]string{
+		"q":             filter.Search,
+		"status":        filter.Status,
+		"tag":           filter.Tag,
+		"changed_since": query.Get("changed_since"),
+		"after":         query.Get("after"),
+	} {
+		if value != "" {
+			filters.Set(key, value)
+		}
+	}
+	filters.Set("sort", filter.Sort)
+	filters.Set("dir", "desc")
+	if filter.Asc {
+		filters.Set("dir", "asc")
+	}
+
+	return filter, filters
 }
 
-// HandleAdminInvitations lists all invitations
+// HandleAdminInvitations lists the invitations, searched, filtered, sorted and paginated from the URL
 func HandleAdminInvitations(s AdminServer) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		_, userName := s.GetCurrentUser(r)
+		lang := adminLanguage(s, r)
 
-		invitations, err := s.GetDB().GetAllInvitationsWithResponses()
+		filter, filters := parseInvitationFilter(r)
+		page, err := s.GetDB().GetInvitationsPage(filter)
 		if err != nil {
 			http.Error(w, "Failed to load invitations", http.StatusInternalServerError)
 			return
 		}
+		tags, err := s.GetDB().GetAllInvitationTags()
+		if err != nil {
+			http.Error(w, "Failed to load invitation tags", http.StatusInternalServerError)
+			return
+		}
+
+		// Highlight answers changed after the configured date, or the one picked in the list
+		since := s.GetConfig().HighlightChangesSince
+		if value := r.URL.Query().Get("changed_since"); value != "" {
+			if parsed, err := time.ParseInLocation("2006-01-02", value, s.GetConfig().RSVPDeadline.Location()); err == nil {
+				since = parsed
+			}
+		}
+		changed := map[int64]bool{}
+		changedSince := ""
+		if !since.IsZero() {
+			changedSince = since.Format("2006-01-02")
+			if changed, err = s.GetDB().GetInvitationsChangedSince(since); err != nil {
+				http.Error(w, "Failed to load changed responses", http.StatusInternalServerError)
+				return
+			}
+		}
 
 		themes := config.GetThemes()
-		if err := templates.AdminInvitationsList(userName, invitations, themes.Light, themes.Dark).Render(r.Context(), w); err != nil {
+		if err := templates.AdminInvitationsList(string(lang), userName, page, filters, database.InvitationStatuses, tags, tagNames(s), changed, changedSince, themes.Light, themes.Dark).Render(r.Context(), w); err != nil {
 			http.Error(w, "Failed to render page", http.StatusInternalServerError)
 		}
 	}
 }
 
+// tagNames returns the tags in use, offered as suggestions in the invitation forms
+func tagNames(s Server) []string {
+	names, err := s.GetDB().GetTagNames()
+	if err != nil {
+		fmt.Printf("Warning: failed to load tags: %v\n", err)
+	}
+	return names
+}
+
 // HandleAdminNewInvitation shows the new invitation form
 func HandleAdminNewInvitation(s AdminServer) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		_, userName := s.GetCurrentUser(r)
+		lang := adminLanguage(s, r)
 		themes := config.GetThemes()
-		if err := templates.AdminNewInvitation(userName, "", themes.Light, themes.Dark).Render(r.Context(), w); err != nil {
+		if err := templates.AdminNewInvitation(string(lang), userName, tagNames(s), "", themes.Light, themes.Dark).Render(r.Context(), w); err != nil {
 			http.Error(w, "Failed to render page", http.StatusInternalServerError)
 		}
 	}
@@ -110,12 +281,13 @@ func HandleAdminNewInvitation(s AdminServer) http.HandlerFunc {
 type invitationFormData struct {
 	guestName string
 	phone     string
+	tags      []string
 }
 
 // parseInvitationForm parses and validates the invitation form
-func parseInvitationForm(r *http.Request, w http.ResponseWriter, userName string, themes config.ThemeConfig) (*invitationFormData, bool) {
+func parseInvitationForm(s Server, r *http.Request, w http.ResponseWriter, lang i18n.Language, userName string, themes config.ThemeConfig) (*invitationFormData, bool) {
 	if err := r.ParseForm(); err != nil {
-		_ = templates.AdminNewInvitation(userName, "Eroare la procesarea formularului", themes.Light, themes.Dark).Render(r.Context(), w)
+		_ = templates.AdminNewInvitation(string(lang), userName, tagNames(s), i18n.T(lang, "error.form_invalid"), themes.Light, themes.Dark).Render(r.Context(), w)
 		return nil, false
 	}
 
@@ -124,44 +296,45 @@ func parseInvitationForm(r *http.Request, w http.ResponseWriter, userName string
 
 	// Validate required fields
 	if guestName == "" || phone == "" {
-		_ = templates.AdminNewInvitation(userName, "Toate câmpurile sunt obligatorii", themes.Light, themes.Dark).Render(r.Context(), w)
+		_ = templates.AdminNewInvitation(string(lang), userName, tagNames(s), i18n.T(lang, "error.required_fields"), themes.Light, themes.Dark).Render(r.Context(), w)
 		return nil, false
 	}
 
 	// Normalize phone number to E.164 format
 	normalizedPhone, err := utils.NormalizePhoneNumber(phone)
 	if err != nil {
-		_ = templates.AdminNewInvitation(userName, "Număr de telefon invalid", themes.Light, themes.Dark).Render(r.Context(), w)
+		_ = templates.AdminNewInvitation(string(lang), userName, tagNames(s), i18n.T(lang, "error.invalid_phone"), themes.Light, themes.Dark).Render(r.Context(), w)
 		return nil, false
 	}
 
 	return &invitationFormData{
 		guestName: guestName,
 		phone:     normalizedPhone,
+		tags:      utils.ParseTags(r.FormValue("tags")),
 	}, true
 }
 
-// createInvitationRecord creates a new invitation in the database
+// createInvitationRecord creates a new invitation with its tags in the database
 func createInvitationRecord(s Server, formData *invitationFormData, messageTemplate string) (*database.Invitation, error) {
-	return s.GetDB().CreateInvitation(formData.guestName, formData.phone, messageTemplate)
+	return s.GetDB().CreateInvitation(formData.guestName, formData.phone, messageTemplate, formData.tags)
 }
 
 // handleInvitationCreationError renders an error message for invitation creation failures
-func handleInvitationCreationError(err error, w http.ResponseWriter, r *http.Request, userName string, themes config.ThemeConfig) {
+func handleInvitationCreationError(s Server, err error, w http.ResponseWriter, r *http.Request, lang i18n.Language, userName string, themes config.ThemeConfig) {
 	if strings.Contains(err.Error(), "UNIQUE constraint failed") {
-		_ = templates.AdminNewInvitation(userName, "Acest număr de telefon există deja", themes.Light, themes.Dark).Render(r.Context(), w)
+		_ = templates.AdminNewInvitation(string(lang), userName, tagNames(s), i18n.T(lang, "error.phone_exists"), themes.Light, themes.Dark).Render(r.Context(), w)
 		return
 	}
-	_ = templates.AdminNewInvitation(userName, "Eroare la crearea invitației", themes.Light, themes.Dark).Render(r.Context(), w)
+	_ = templates.AdminNewInvitation(string(lang), userName, tagNames(s), i18n.T(lang, "error.create_failed"), themes.Light, themes.Dark).Render(r.Context(), w)
 }
 
 // createInvitationWithMessage creates an invitation and updates its message with the token
-func createInvitationWithMessage(s Server, formData *invitationFormData, messageTemplate string, w http.ResponseWriter, r *http.Request, userName string, themes config.ThemeConfig) bool {
+func createInvitationWithMessage(s Server, formData *invitationFormData, messageTemplate string, w http.ResponseWriter, r *http.Request, lang i18n.Language, userName string, themes config.ThemeConfig) (*database.Invitation, bool) {
 	// Create invitation (this will generate the token)
 	inv, err := createInvitationRecord(s, formData, messageTemplate)
 	if err != nil {
-		handleInvitationCreationError(err, w, r, userName, themes)
-		return false
+		handleInvitationCreationError(s, err, w, r, lang, userName, themes)
+		return nil, false
 	}
 
 	// Update the message with the actual token
@@ -170,14 +343,15 @@ func createInvitationWithMessage(s Server, formData *invitationFormData, message
 		fmt.Printf("Warning: failed to update invite message: %v\n", err)
 	}
 
-	return true
+	return inv, true
 }
 
 // updateInvitationMessage replaces placeholders in the message template and updates the invitation
 func updateInvitationMessage(s Server, inv *database.Invitation, messageTemplate string) error {
-	rsvpLink := fmt.Sprintf("%s/rsvp/%s", s.GetConfig().BaseURL, inv.Token)
+	rsvpLink := rsvpURL(s.GetConfig(), inv.Token)
 	finalMessage := strings.Replace(messageTemplate, "{{TOKEN}}", inv.Token, 1)
 	finalMessage = strings.Replace(finalMessage, "{{RSVP_LINK}}", rsvpLink, 1)
+	finalMessage = strings.Replace(finalMessage, "{{CODE}}", inv.Code, 1)
 
 	_, err := s.GetDB().Exec("UPDATE invitations SET invite_message = $1 WHERE id = $2", finalMessage, inv.ID)
 	return err
@@ -191,6 +365,8 @@ Cu multă bucurie și emoție, vă invităm să fiți alături de noi la botezul
 
 {{RSVP_LINK}}
 
+Codul invitației: {{CODE}}
+
 Evenimentul va avea loc pe 19 Aprilie 2026
 
 Cu drag,
@@ -206,10 +382,11 @@ func HandleAdminCreateInvitation(s AdminServer) http.HandlerFunc {
 		}
 
 		_, userName := s.GetCurrentUser(r)
+		adminLang := adminLanguage(s, r)
 		themes := config.GetThemes()
 
 		// Parse and validate form
-		formData, ok := parseInvitationForm(r, w, userName, themes)
+		formData, ok := parseInvitationForm(s, r, w, adminLang, userName, themes)
 		if !ok {
 			return
 		}
@@ -219,10 +396,15 @@ func HandleAdminCreateInvitation(s AdminServer) http.HandlerFunc {
 		inviteMessageTemplate := generateInviteMessageTemplate(s, formData.guestName, lang)
 
 		// Create invitation and update message
-		if !createInvitationWithMessage(s, formData, inviteMessageTemplate, w, r, userName, themes) {
+		inv, ok := createInvitationWithMessage(s, formData, inviteMessageTemplate, w, r, adminLang, userName, themes)
+		if !ok {
 			return
 		}
 
+		after := snapshotInvitation(inv, nil)
+		after.Tags = formData.tags
+		recordAudit(s, r, database.AuditInvitationCreate, inv.ID, inv.GuestName, nil, after)
+
 		// Redirect to list
 		http.Redirect(w, r, "/admin/invitations", http.StatusSeeOther)
 	}
@@ -236,11 +418,74 @@ func HandleAdminMarkSent(s AdminServer) http.HandlerFunc {
 			return
 		}
 
+		before, err := s.GetDB().GetInvitationByID(id)
+		if err != nil || before.DeletedAt.Valid {
+			http.Error(w, "Invitation not found", http.StatusNotFound)
+			return
+		}
+
 		if err := s.GetDB().MarkAsSent(id); err != nil {
 			http.Error(w, "Failed to mark as sent", http.StatusInternalServerError)
 			return
 		}
 
+		if after, err := s.GetDB().GetInvitationByID(id); err == nil {
+			recordAudit(s, r, database.AuditInvitationMarkSent, id, after.GuestName, snapshotInvitation(before, nil), snapshotInvitation(after, nil))
+		}
+
+		http.Redirect(w, r, "/admin/invitations", http.StatusSeeOther)
+	}
+}
+
+// HandleAdminRegenerateToken gives an invitation a new link, invalidating the old one
+func HandleAdminRegenerateToken(s AdminServer) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id, ok := parseFormID(r, w)
+		if !ok {
+			return
+		}
+
+		before, err := s.GetDB().GetInvitationByID(id)
+		if err != nil || before.DeletedAt.Valid {
+			http.Error(w, "Invitation not found", http.StatusNotFound)
+			return
+		}
+
+		after, err := s.GetDB().RegenerateInvitationToken(id)
+		if err != nil {
+			http.Error(w, "Failed to regenerate invitation link", http.StatusInternalServerError)
+			return
+		}
+
+		recordAudit(s, r, database.AuditInvitationNewLink, id, after.GuestName, snapshotInvitation(before, nil), snapshotInvitation(after, nil))
+
+		http.Redirect(w, r, "/admin/invitations", http.StatusSeeOther)
+	}
+}
+
+// HandleAdminRevokeInvitation disables an invitation's link
+func HandleAdminRevokeInvitation(s AdminServer) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id, ok := parseFormID(r, w)
+		if !ok {
+			return
+		}
+
+		before, err := s.GetDB().GetInvitationByID(id)
+		if err != nil || before.DeletedAt.Valid {
+			http.Error(w, "Invitation not found", http.StatusNotFound)
+			return
+		}
+
+		if err := s.GetDB().RevokeInvitation(id); err != nil {
+			http.Error(w, "Failed to revoke invitation link", http.StatusInternalServerError)
+			return
+		}
+
+		if after, err := s.GetDB().GetInvitationByID(id); err == nil {
+			recordAudit(s, r, database.AuditInvitationRevoke, id, after.GuestName, snapshotInvitation(before, nil), snapshotInvitation(after, nil))
+		}
+
 		http.Redirect(w, r, "/admin/invitations", http.StatusSeeOther)
 	}
 }
@@ -249,6 +494,7 @@ func HandleAdminMarkSent(s AdminServer) http.HandlerFunc {
 func HandleAdminEditInvitation(s AdminServer) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		_, userName := s.GetCurrentUser(r)
+		lang := adminLanguage(s, r)
 		themes := config.GetThemes()
 
 		// Extract ID from URL path
@@ -260,12 +506,18 @@ func HandleAdminEditInvitation(s AdminServer) http.HandlerFunc {
 		}
 
 		invitation, err := s.GetDB().GetInvitationByID(id)
-		if err != nil {
+		if err != nil || invitation.DeletedAt.Valid {
 			http.Error(w, "Invitation not found", http.StatusNotFound)
 			return
 		}
 
-		if err := templates.AdminEditInvitation(userName, invitation, "", themes.Light, themes.Dark).Render(r.Context(), w); err != nil {
+		tags, err := s.GetDB().GetInvitationTags(id)
+		if err != nil {
+			http.Error(w, "Failed to load invitation tags", http.StatusInternalServerError)
+			return
+		}
+
+		if err := templates.AdminEditInvitation(string(lang), userName, invitation, tags, tagNames(s), "", themes.Light, themes.Dark).Render(r.Context(), w); err != nil {
 			http.Error(w, "Failed to render page", http.StatusInternalServerError)
 		}
 	}
@@ -280,6 +532,7 @@ func HandleAdminUpdateInvitation(s AdminServer) http.HandlerFunc {
 		}
 
 		_, userName := s.GetCurrentUser(r)
+		lang := adminLanguage(s, r)
 		themes := config.GetThemes()
 
 		// Extract ID from URL path
@@ -295,35 +548,50 @@ func HandleAdminUpdateInvitation(s AdminServer) http.HandlerFunc {
 			return
 		}
 
+		before, err := s.GetDB().GetInvitationByID(id)
+		if err != nil || before.DeletedAt.Valid {
+			http.Error(w, "Invitation not found", http.StatusNotFound)
+			return
+		}
+
 		guestName := strings.TrimSpace(r.FormValue("guest_name"))
 		phone := strings.TrimSpace(r.FormValue("phone"))
+		tags := utils.ParseTags(r.FormValue("tags"))
 
 		if guestName == "" || phone == "" {
-			invitation, _ := s.GetDB().GetInvitationByID(id)
-			_ = templates.AdminEditInvitation(userName, invitation, "Toate câmpurile sunt obligatorii", themes.Light, themes.Dark).Render(r.Context(), w)
+			_ = templates.AdminEditInvitation(string(lang), userName, before, tags, tagNames(s), i18n.T(lang, "error.required_fields"), themes.Light, themes.Dark).Render(r.Context(), w)
 			return
 		}
 
 		// Normalize phone number to E.164 format
 		normalizedPhone, err := utils.NormalizePhoneNumber(phone)
 		if err != nil {
-			invitation, _ := s.GetDB().GetInvitationByID(id)
-			_ = templates.AdminEditInvitation(userName, invitation, "Număr de telefon invalid", themes.Light, themes.Dark).Render(r.Context(), w)
+			_ = templates.AdminEditInvitation(string(lang), userName, before, tags, tagNames(s), i18n.T(lang, "error.invalid_phone"), themes.Light, themes.Dark).Render(r.Context(), w)
 			return
 		}
 		phone = normalizedPhone
+		beforeTags, err := s.GetDB().GetInvitationTags(id)
+		if err != nil {
+			http.Error(w, "Failed to load invitation tags", http.StatusInternalServerError)
+			return
+		}
 
-		if err := s.GetDB().UpdateInvitation(id, guestName, phone); err != nil {
-			invitation, _ := s.GetDB().GetInvitationByID(id)
-			_ = templates.AdminEditInvitation(userName, invitation, "Eroare la actualizare. Verifică dacă numărul de telefon nu este deja folosit.", themes.Light, themes.Dark).Render(r.Context(), w)
+		if err := s.GetDB().UpdateInvitation(id, guestName, phone, tags); err != nil {
+			_ = templates.AdminEditInvitation(string(lang), userName, before, tags, tagNames(s), i18n.T(lang, "error.update_failed"), themes.Light, themes.Dark).Render(r.Context(), w)
 			return
 		}
 
+		if after, err := s.GetDB().GetInvitationByID(id); err == nil {
+			beforeSnapshot, afterSnapshot := snapshotInvitation(before, nil), snapshotInvitation(after, nil)
+			beforeSnapshot.Tags, afterSnapshot.Tags = beforeTags, tags
+			recordAudit(s, r, database.AuditInvitationUpdate, id, after.GuestName, beforeSnapshot, afterSnapshot)
+		}
+
 		http.Redirect(w, r, "/admin/invitations", http.StatusSeeOther)
 	}
 }
 
-// HandleAdminDeleteInvitation deletes an invitation
+// HandleAdminDeleteInvitation archives an invitation; it can be restored from the archive until it is purged
 func HandleAdminDeleteInvitation(s AdminServer) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		id, ok := parseFormID(r, w)
@@ -331,11 +599,138 @@ func HandleAdminDeleteInvitation(s AdminServer) http.HandlerFunc {
 			return
 		}
 
-		if err := s.GetDB().DeleteInvitation(id); err != nil {
-			http.Error(w, "Failed to delete invitation", http.StatusInternalServerError)
+		before, err := s.GetDB().GetInvitationByID(id)
+		if err != nil || before.DeletedAt.Valid {
+			http.Error(w, "Invitation not found", http.StatusNotFound)
 			return
 		}
 
+		if err := s.GetDB().ArchiveInvitation(id); err != nil {
+			http.Error(w, "Failed to archive invitation", http.StatusInternalServerError)
+			return
+		}
+
+		recordAudit(s, r, database.AuditInvitationArchive, id, before.GuestName, snapshotInvitation(before, nil), nil)
+
 		http.Redirect(w, r, "/admin/invitations", http.StatusSeeOther)
 	}
 }
+
+// HandleAdminArchive lists the archived invitations
+func HandleAdminArchive(s AdminServer) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		_, userName := s.GetCurrentUser(r)
+		lang := adminLanguage(s, r)
+
+		invitations, err := s.GetDB().GetArchivedInvitations()
+		if err != nil {
+			http.Error(w, "Failed to load archived invitations", http.StatusInternalServerError)
+			return
+		}
+
+		themes := config.GetThemes()
+		purgeAfter := s.GetConfig().ArchivePurgeAfter
+		if err := templates.AdminArchive(string(lang), userName, invitations, purgeAfter, r.URL.Query().Get("error"), themes.Light, themes.Dark).Render(r.Context(), w); err != nil {
+			http.Error(w, "Failed to render page", http.StatusInternalServerError)
+		}
+	}
+}
+
+// HandleAdminRestoreInvitation brings an archived invitation back
+func HandleAdminRestoreInvitation(s AdminServer) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id, ok := parseFormID(r, w)
+		if !ok {
+			return
+		}
+
+		invitation, err := s.GetDB().GetInvitationByID(id)
+		if err != nil || !invitation.DeletedAt.Valid {
+			http.Error(w, "Invitation not found", http.StatusNotFound)
+			return
+		}
+
+		// The phone number may have been given to a new invitation in the meantime
+		if _, err := s.GetDB().GetInvitationByPhone(invitation.Phone); err == nil {
+			http.Redirect(w, r, "/admin/invitations/archive?error=phone", http.StatusSeeOther)
+			return
+		}
+
+		if err := s.GetDB().RestoreInvitation(id); err != nil {
+			http.Error(w, "Failed to restore invitation", http.StatusInternalServerError)
+			return
+		}
+
+		recordAudit(s, r, database.AuditInvitationRestore, id, invitation.GuestName, nil, snapshotInvitation(invitation, nil))
+
+		http.Redirect(w, r, "/admin/invitations/archive", http.StatusSeeOther)
+	}
+}
+
+// HandleAdminPurgeInvitation permanently deletes an archived invitation and all its responses
+func HandleAdminPurgeInvitation(s AdminServer) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id, ok := parseFormID(r, w)
+		if !ok {
+			return
+		}
+
+		before, err := s.GetDB().GetInvitationByID(id)
+		if err != nil || !before.DeletedAt.Valid {
+			http.Error(w, "Invitation not found", http.StatusNotFound)
+			return
+		}
+
+		snapshot, err := purgeInvitation(s.GetDB(), before)
+		if err != nil {
+			http.Error(w, "Failed to delete invitation", http.StatusInternalServerError)
+			return
+		}
+
+		recordAudit(s, r, database.AuditInvitationDelete, id, before.GuestName, snapshot, nil)
+
+		http.Redirect(w, r, "/admin/invitations/archive", http.StatusSeeOther)
+	}
+}
+
+// PurgeExpiredInvitations permanently deletes the invitations archived before a time and returns how many were deleted
+// Each one is recorded in the audit log like a manual purge, with the system as the actor
+func PurgeExpiredInvitations(db *database.DB, before time.Time) (int, error) {
+	invitations, err := db.GetInvitationsArchivedBefore(before)
+	if err != nil {
+		return 0, err
+	}
+
+	for i, inv := range invitations {
+		snapshot, err := purgeInvitation(db, inv)
+		if err != nil {
+			return i, err
+		}
+
+		entry := &database.AuditEntry{
+			ActorEmail:   database.AuditSystemActor,
+			Action:       database.AuditInvitationDelete,
+			InvitationID: sql.NullInt64{Int64: inv.ID, Valid: true},
+			TargetName:   sql.NullString{String: inv.GuestName, Valid: inv.GuestName != ""},
+			BeforeValue:  toAuditValue(snapshot),
+		}
+		if err := db.CreateAuditEntry(entry); err != nil {
+			// Log but don't stop - the invitation is already gone
+			fmt.Printf("Warning: failed to record audit entry: %v\n", err)
+		}
+	}
+	return len(invitations), nil
+}
+
+// purgeInvitation permanently deletes an archived invitation and returns a snapshot
+// of it and its latest response, taken before they are gone, for the audit log
+func purgeInvitation(db *database.DB, inv *database.Invitation) (*invitationSnapshot, error) {
+	response, err := db.GetLatestResponseByInvitationID(inv.ID)
+	if err != nil {
+		return nil, err
+	}
+	if err := db.DeleteInvitation(inv.ID); err != nil {
+		return nil, err
+	}
+	return snapshotInvitation(inv, response), nil
+}
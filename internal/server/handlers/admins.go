@@ -0,0 +1,203 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/mail"
+	"strings"
+
+	"github.com/AlexTLDR/evite/internal/auth"
+	"github.com/AlexTLDR/evite/internal/config"
+	"github.com/AlexTLDR/evite/internal/database"
+	"github.com/AlexTLDR/evite/internal/i18n"
+	"github.com/AlexTLDR/evite/templates"
+)
+
+// adminSnapshot is the JSON representation of an admin stored in the audit log
+type adminSnapshot struct {
+	Email string `json:"email"`
+	Role  string `json:"role"`
+}
+
+// renderAdminsPage renders the admin management page with an optional error message
+func renderAdminsPage(s AdminServer, w http.ResponseWriter, r *http.Request, lang i18n.Language, errorMsg string) {
+	email, userName := s.GetCurrentUser(r)
+
+	admins, err := s.GetDB().GetAllAdmins()
+	if err != nil {
+		http.Error(w, "Failed to load admins", http.StatusInternalServerError)
+		return
+	}
+
+	if errorMsg != "" {
+		w.WriteHeader(http.StatusBadRequest)
+	}
+
+	themes := config.GetThemes()
+	loginURL := s.GetConfig().BaseURL + "/admin"
+	if err := templates.AdminAdmins(string(lang), userName, email, admins, auth.Roles, loginURL, errorMsg, themes.Light, themes.Dark).Render(r.Context(), w); err != nil {
+		http.Error(w, "Failed to render page", http.StatusInternalServerError)
+	}
+}
+
+// parseAdminForm parses a POST form on the admin management page
+// Returns false if the request was already answered
+func parseAdminForm(w http.ResponseWriter, r *http.Request) bool {
+	if r.Method != http.MethodPost {
+		http.Redirect(w, r, "/admin/admins", http.StatusSeeOther)
+		return false
+	}
+
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, "Invalid form", http.StatusBadRequest)
+		return false
+	}
+
+	return true
+}
+
+// parseAdminFormID parses the admin ID from the form and loads the admin
+// Returns nil if the request was already answered
+func parseAdminFormID(s AdminServer, w http.ResponseWriter, r *http.Request) *database.Admin {
+	id, err := parseID(r.FormValue("id"))
+	if err != nil {
+		http.Error(w, "Invalid admin ID", http.StatusBadRequest)
+		return nil
+	}
+
+	admin, err := s.GetDB().GetAdminByID(id)
+	if err != nil {
+		http.Error(w, "Admin not found", http.StatusNotFound)
+		return nil
+	}
+
+	return admin
+}
+
+// HandleAdminAdmins renders the admin management page
+func HandleAdminAdmins(s AdminServer) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		renderAdminsPage(s, w, r, adminLanguage(s, r), "")
+	}
+}
+
+// HandleAdminCreateAdmin adds a co-host with the chosen role
+func HandleAdminCreateAdmin(s AdminServer) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if !parseAdminForm(w, r) {
+			return
+		}
+		lang := adminLanguage(s, r)
+		currentEmail, _ := s.GetCurrentUser(r)
+
+		email := strings.ToLower(strings.TrimSpace(r.FormValue("email")))
+		if addr, err := mail.ParseAddress(email); err != nil || addr.Address != email {
+			renderAdminsPage(s, w, r, lang, i18n.T(lang, "admins.error_email"))
+			return
+		}
+
+		role, ok := auth.ParseRole(r.FormValue("role"))
+		if !ok {
+			renderAdminsPage(s, w, r, lang, i18n.T(lang, "admins.error_role"))
+			return
+		}
+
+		existing, err := s.GetDB().GetAdminByEmail(email)
+		if err != nil {
+			http.Error(w, "Failed to check admin", http.StatusInternalServerError)
+			return
+		}
+		if existing != nil {
+			renderAdminsPage(s, w, r, lang, i18n.T(lang, "admins.error_exists"))
+			return
+		}
+
+		admin, err := s.GetDB().CreateAdmin(email, string(role), currentEmail)
+		if err != nil {
+			http.Error(w, "Failed to create admin", http.StatusInternalServerError)
+			return
+		}
+
+		recordAudit(s, r, database.AuditAdminCreate, 0, admin.Email, nil, adminSnapshot{Email: admin.Email, Role: admin.Role})
+
+		http.Redirect(w, r, "/admin/admins", http.StatusSeeOther)
+	}
+}
+
+// HandleAdminUpdateAdminRole changes the role of an admin
+func HandleAdminUpdateAdminRole(s AdminServer) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if !parseAdminForm(w, r) {
+			return
+		}
+		lang := adminLanguage(s, r)
+
+		admin := parseAdminFormID(s, w, r)
+		if admin == nil {
+			return
+		}
+
+		role, ok := auth.ParseRole(r.FormValue("role"))
+		if !ok {
+			renderAdminsPage(s, w, r, lang, i18n.T(lang, "admins.error_role"))
+			return
+		}
+
+		err := s.GetDB().UpdateAdminRole(admin.ID, string(role))
+		if errors.Is(err, database.ErrLastOwner) {
+			renderAdminsPage(s, w, r, lang, i18n.T(lang, "admins.error_last_owner"))
+			return
+		}
+		if err != nil {
+			http.Error(w, "Failed to update admin role", http.StatusInternalServerError)
+			return
+		}
+
+		recordAudit(s, r, database.AuditAdminRoleChange, 0, admin.Email,
+			adminSnapshot{Email: admin.Email, Role: admin.Role},
+			adminSnapshot{Email: admin.Email, Role: string(role)})
+
+		http.Redirect(w, r, "/admin/admins", http.StatusSeeOther)
+	}
+}
+
+// HandleAdminDeleteAdmin removes an admin
+func HandleAdminDeleteAdmin(s AdminServer) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if !parseAdminForm(w, r) {
+			return
+		}
+		lang := adminLanguage(s, r)
+		currentEmail, _ := s.GetCurrentUser(r)
+
+		admin := parseAdminFormID(s, w, r)
+		if admin == nil {
+			return
+		}
+
+		if strings.EqualFold(admin.Email, currentEmail) {
+			renderAdminsPage(s, w, r, lang, i18n.T(lang, "admins.error_self"))
+			return
+		}
+
+		err := s.GetDB().DeleteAdmin(admin.ID)
+		if errors.Is(err, database.ErrLastOwner) {
+			renderAdminsPage(s, w, r, lang, i18n.T(lang, "admins.error_last_owner"))
+			return
+		}
+		if err != nil {
+			http.Error(w, "Failed to delete admin", http.StatusInternalServerError)
+			return
+		}
+
+		// Sign the removed admin out everywhere
+		if err := s.GetDB().DeleteAdminSessionsByEmail(admin.Email); err != nil {
+			fmt.Printf("Warning: failed to delete sessions of removed admin: %v\n", err)
+		}
+
+		recordAudit(s, r, database.AuditAdminDelete, 0, admin.Email, adminSnapshot{Email: admin.Email, Role: admin.Role}, nil)
+
+		http.Redirect(w, r, "/admin/admins", http.StatusSeeOther)
+	}
+}
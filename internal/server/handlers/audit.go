@@ -0,0 +1,194 @@
+package handlers
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/AlexTLDR/evite/internal/config"
+	"github.com/AlexTLDR/evite/internal/database"
+	"github.com/AlexTLDR/evite/internal/i18n"
+	"github.com/AlexTLDR/evite/internal/utils"
+	"github.com/AlexTLDR/evite/templates"
+)
+
+// auditPageLimit is the maximum number of entries shown on the audit page
+const auditPageLimit = 500
+
+// invitationSnapshot is the JSON representation of an invitation stored in the audit log
+type invitationSnapshot struct {
+	GuestName   string            `json:"guest_name"`
+	Phone       string            `json:"phone"`
+	SentAt      *time.Time        `json:"sent_at,omitempty"`
+	OpenedAt    *time.Time        `json:"opened_at,omitempty"`
+	RespondedAt *time.Time        `json:"responded_at,omitempty"`
+	RevokedAt   *time.Time        `json:"revoked_at,omitempty"`
+	Tags        []string          `json:"tags,omitempty"`
+	Response    *responseSnapshot `json:"response,omitempty"`
+}
+
+// responseSnapshot is the JSON representation of a response stored in the audit log
+type responseSnapshot struct {
+	Attending               bool   `json:"attending"`
+	PlusOne                 bool   `json:"plus_one"`
+	KidsCount               int    `json:"kids_count"`
+	MenuPreference          string `json:"menu_preference,omitempty"`
+	CompanionMenuPreference string `json:"companion_menu_preference,omitempty"`
+	Comment                 string `json:"comment,omitempty"`
+}
+
+// nullTimePtr converts a sql.NullTime to a pointer (nil when not set)
+func nullTimePtr(t sql.NullTime) *time.Time {
+	if !t.Valid {
+		return nil
+	}
+	return &t.Time
+}
+
+// snapshotInvitation converts an invitation (and optionally its latest response) to an audit snapshot
+func snapshotInvitation(inv *database.Invitation, response *database.Response) *invitationSnapshot {
+	snapshot := &invitationSnapshot{
+		GuestName:   inv.GuestName,
+		Phone:       inv.Phone,
+		SentAt:      nullTimePtr(inv.SentAt),
+		OpenedAt:    nullTimePtr(inv.OpenedAt),
+		RespondedAt: nullTimePtr(inv.RespondedAt),
+		RevokedAt:   nullTimePtr(inv.RevokedAt),
+	}
+	if response != nil {
+		snapshot.Response = &responseSnapshot{
+			Attending:               response.Attending,
+			PlusOne:                 response.PlusOne,
+			KidsCount:               response.KidsCount,
+			MenuPreference:          response.MenuPreference.String,
+			CompanionMenuPreference: response.CompanionMenuPreference.String,
+			Comment:                 response.Comment.String,
+		}
+	}
+	return snapshot
+}
+
+// toAuditValue marshals a snapshot to JSON for the audit log (NULL when value is nil)
+func toAuditValue(value interface{}) sql.NullString {
+	if value == nil {
+		return sql.NullString{}
+	}
+	data, err := json.Marshal(value)
+	if err != nil {
+		fmt.Printf("Warning: failed to marshal audit value: %v\n", err)
+		return sql.NullString{}
+	}
+	return sql.NullString{String: string(data), Valid: true}
+}
+
+// recordAudit stores an audit entry for an admin action
+// invitationID and targetName may be empty for actions that don't target an invitation
+func recordAudit(s AdminServer, r *http.Request, action string, invitationID int64, targetName string, before, after interface{}) {
+	email, _ := s.GetCurrentUser(r)
+	entry := &database.AuditEntry{
+		ActorEmail:   email,
+		Action:       action,
+		InvitationID: sql.NullInt64{Int64: invitationID, Valid: invitationID > 0},
+		TargetName:   sql.NullString{String: targetName, Valid: targetName != ""},
+		BeforeValue:  toAuditValue(before),
+		AfterValue:   toAuditValue(after),
+		IP:           sql.NullString{String: utils.ClientIP(r), Valid: true},
+	}
+
+	if err := s.GetDB().CreateAuditEntry(entry); err != nil {
+		// Log but don't fail - the action itself already succeeded
+		fmt.Printf("Warning: failed to record audit entry: %v\n", err)
+	}
+}
+
+// parseAuditFilter reads the audit filters from the query string
+// Dates use the YYYY-MM-DD format of <input type="date">; "to" is inclusive
+func parseAuditFilter(r *http.Request) database.AuditFilter {
+	query := r.URL.Query()
+	filter := database.AuditFilter{
+		ActorEmail: strings.TrimSpace(query.Get("actor")),
+		Action:     query.Get("action"),
+	}
+
+	if id, err := parseID(query.Get("invitation")); err == nil {
+		filter.InvitationID = id
+	}
+	if from, err := time.ParseInLocation("2006-01-02", query.Get("from"), time.Local); err == nil {
+		filter.From = from
+	}
+	if to, err := time.ParseInLocation("2006-01-02", query.Get("to"), time.Local); err == nil {
+		filter.To = to.AddDate(0, 0, 1)
+	}
+
+	return filter
+}
+
+// HandleAdminAudit renders the audit log with filters
+func HandleAdminAudit(s AdminServer) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		_, userName := s.GetCurrentUser(r)
+		lang := adminLanguage(s, r)
+
+		filter := parseAuditFilter(r)
+		filter.Limit = auditPageLimit
+
+		entries, err := s.GetDB().GetAuditEntries(filter)
+		if err != nil {
+			http.Error(w, "Failed to load audit log", http.StatusInternalServerError)
+			return
+		}
+
+		themes := config.GetThemes()
+		if err := templates.AdminAudit(string(lang), userName, entries, r.URL.Query(), database.AuditActions, auditPageLimit, themes.Light, themes.Dark).Render(r.Context(), w); err != nil {
+			http.Error(w, "Failed to render page", http.StatusInternalServerError)
+		}
+	}
+}
+
+// HandleAdminAuditCSV exports the filtered audit log to CSV
+func HandleAdminAuditCSV(s AdminServer) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		lang := adminLanguage(s, r)
+
+		entries, err := s.GetDB().GetAuditEntries(parseAuditFilter(r))
+		if err != nil {
+			http.Error(w, "Failed to load audit log", http.StatusInternalServerError)
+			return
+		}
+
+		recordAudit(s, r, database.AuditExportAuditCSV, 0, "", nil, r.URL.Query())
+
+		w.Header().Set("Content-Type", "text/csv; charset=utf-8")
+		w.Header().Set("Content-Disposition", "attachment; filename=audit-log.csv")
+
+		// Write UTF-8 BOM for Excel compatibility
+		w.Write([]byte{0xEF, 0xBB, 0xBF})
+
+		headers := []string{
+			i18n.T(lang, "audit.col_when"), i18n.T(lang, "audit.col_actor"), i18n.T(lang, "audit.col_action"),
+			i18n.T(lang, "audit.col_invitation"), i18n.T(lang, "audit.col_target"), i18n.T(lang, "audit.col_before"),
+			i18n.T(lang, "audit.col_after"), i18n.T(lang, "audit.col_ip"),
+		}
+		w.Write([]byte(strings.Join(headers, ",") + "\n"))
+
+		for _, entry := range entries {
+			invitationID := ""
+			if entry.InvitationID.Valid {
+				invitationID = fmt.Sprintf("%d", entry.InvitationID.Int64)
+			}
+			line := fmt.Sprintf("\"%s\",\"%s\",\"%s\",\"%s\",\"%s\",\"%s\",\"%s\",\"%s\"\n",
+				entry.CreatedAt.Format("2006-01-02 15:04:05"),
+				escapeCSVField(entry.ActorEmail),
+				escapeCSVField(i18n.T(lang, "audit.action."+entry.Action)),
+				invitationID,
+				escapeCSVField(entry.TargetName.String),
+				escapeCSVField(entry.BeforeValue.String),
+				escapeCSVField(entry.AfterValue.String),
+				escapeCSVField(entry.IP.String))
+			w.Write([]byte(line))
+		}
+	}
+}
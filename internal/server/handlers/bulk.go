@@ -0,0 +1,221 @@
+package handlers
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/AlexTLDR/evite/internal/config"
+	"github.com/AlexTLDR/evite/internal/database"
+	"github.com/AlexTLDR/evite/internal/utils"
+	"github.com/AlexTLDR/evite/templates"
+)
+
+// parseBulkIDs reads the IDs of the invitations selected in the list, or of every invitation matching
+// the list filters when "all" is set, so that a segment larger than one page can be acted on at once
+// Requests that are not POST or have nothing selected are redirected back to the list
+func parseBulkIDs(s AdminServer, r *http.Request, w http.ResponseWriter) ([]int64, bool) {
+	if r.Method != http.MethodPost {
+		http.Redirect(w, r, "/admin/invitations", http.StatusSeeOther)
+		return nil, false
+	}
+
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, "Invalid form", http.StatusBadRequest)
+		return nil, false
+	}
+
+	var ids []int64
+	if r.PostForm.Get("all") == "1" {
+		page, err := s.GetDB().GetInvitationsPage(database.InvitationFilter{
+			Search: strings.TrimSpace(r.PostForm.Get("filter_q")),
+			Status: r.PostForm.Get("filter_status"),
+			Tag:    strings.TrimSpace(r.PostForm.Get("filter_tag")),
+		})
+		if err != nil {
+			http.Error(w, "Failed to load invitations", http.StatusInternalServerError)
+			return nil, false
+		}
+		for _, inv := range page.Invitations {
+			ids = append(ids, inv.ID)
+		}
+	} else {
+		for _, idStr := range r.PostForm["id"] {
+			id, err := parseID(idStr)
+			if err != nil {
+				http.Error(w, "Invalid invitation ID", http.StatusBadRequest)
+				return nil, false
+			}
+			ids = append(ids, id)
+		}
+	}
+	if len(ids) == 0 {
+		http.Redirect(w, r, "/admin/invitations", http.StatusSeeOther)
+		return nil, false
+	}
+
+	return ids, true
+}
+
+// renderBulkResult shows the summary of a bulk action
+func renderBulkResult(s AdminServer, w http.ResponseWriter, r *http.Request, action string, result *database.BulkResult) {
+	_, userName := s.GetCurrentUser(r)
+	lang := adminLanguage(s, r)
+	themes := config.GetThemes()
+
+	if err := templates.AdminBulkResult(string(lang), userName, action, result, themes.Light, themes.Dark).Render(r.Context(), w); err != nil {
+		http.Error(w, "Failed to render page", http.StatusInternalServerError)
+	}
+}
+
+// HandleAdminBulkMarkSent marks the selected invitations as sent in one transaction
+func HandleAdminBulkMarkSent(s AdminServer) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ids, ok := parseBulkIDs(s, r, w)
+		if !ok {
+			return
+		}
+
+		result, err := s.GetDB().BulkMarkAsSent(ids)
+		if err != nil {
+			http.Error(w, "Failed to mark invitations as sent", http.StatusInternalServerError)
+			return
+		}
+
+		for _, before := range result.Succeeded {
+			if after, err := s.GetDB().GetInvitationByID(before.ID); err == nil {
+				recordAudit(s, r, database.AuditInvitationMarkSent, before.ID, after.GuestName, snapshotInvitation(before, nil), snapshotInvitation(after, nil))
+			}
+		}
+
+		renderBulkResult(s, w, r, "mark_sent", result)
+	}
+}
+
+// HandleAdminBulkArchive archives the selected invitations in one transaction
+func HandleAdminBulkArchive(s AdminServer) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ids, ok := parseBulkIDs(s, r, w)
+		if !ok {
+			return
+		}
+
+		result, err := s.GetDB().BulkArchive(ids)
+		if err != nil {
+			http.Error(w, "Failed to archive invitations", http.StatusInternalServerError)
+			return
+		}
+
+		for _, before := range result.Succeeded {
+			recordAudit(s, r, database.AuditInvitationArchive, before.ID, before.GuestName, snapshotInvitation(before, nil), nil)
+		}
+
+		renderBulkResult(s, w, r, "archive", result)
+	}
+}
+
+// HandleAdminBulkTag adds the comma-separated tags to the selected invitations in one transaction
+func HandleAdminBulkTag(s AdminServer) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ids, ok := parseBulkIDs(s, r, w)
+		if !ok {
+			return
+		}
+
+		tags := utils.ParseTags(r.PostForm.Get("tag"))
+		if len(tags) == 0 {
+			http.Redirect(w, r, "/admin/invitations", http.StatusSeeOther)
+			return
+		}
+
+		result, err := s.GetDB().BulkAddTags(ids, tags)
+		if err != nil {
+			http.Error(w, "Failed to tag invitations", http.StatusInternalServerError)
+			return
+		}
+
+		for _, inv := range result.Succeeded {
+			recordAudit(s, r, database.AuditInvitationTag, inv.ID, inv.GuestName, nil, tags)
+		}
+
+		renderBulkResult(s, w, r, "tag", result)
+	}
+}
+
+// HandleAdminBulkSend lists the selected invitations with a link that opens the chosen channel
+// (WhatsApp or SMS) with each guest's invite message, so they can be sent one after another
+func HandleAdminBulkSend(s AdminServer) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ids, ok := parseBulkIDs(s, r, w)
+		if !ok {
+			return
+		}
+
+		channel := r.PostForm.Get("channel")
+		if channel != utils.ChannelWhatsApp && channel != utils.ChannelSMS {
+			http.Error(w, "Invalid channel", http.StatusBadRequest)
+			return
+		}
+
+		var entries []templates.BulkSendEntry
+		var failed []database.BulkFailure
+		seen := make(map[int64]bool, len(ids))
+		for _, id := range ids {
+			if seen[id] {
+				continue
+			}
+			seen[id] = true
+
+			inv, err := s.GetDB().GetInvitationByID(id)
+			switch {
+			case err != nil:
+				failed = append(failed, database.BulkFailure{InvitationID: id, Reason: database.BulkSkipNotFound})
+			case inv.DeletedAt.Valid:
+				failed = append(failed, database.BulkFailure{InvitationID: id, GuestName: inv.GuestName, Reason: database.BulkSkipArchived})
+			case inv.RevokedAt.Valid:
+				failed = append(failed, database.BulkFailure{InvitationID: id, GuestName: inv.GuestName, Reason: database.BulkSkipRevoked})
+			default:
+				link, _ := utils.MessageLink(channel, inv.Phone, inv.InviteMessage)
+				entries = append(entries, templates.BulkSendEntry{Invitation: inv, Link: link})
+			}
+		}
+
+		_, userName := s.GetCurrentUser(r)
+		lang := adminLanguage(s, r)
+		themes := config.GetThemes()
+		if err := templates.AdminBulkSend(string(lang), userName, channel, entries, failed, themes.Light, themes.Dark).Render(r.Context(), w); err != nil {
+			http.Error(w, "Failed to render page", http.StatusInternalServerError)
+		}
+	}
+}
+
+// HandleAdminBulkExport exports the selected invitations to CSV
+func HandleAdminBulkExport(s AdminServer) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ids, ok := parseBulkIDs(s, r, w)
+		if !ok {
+			return
+		}
+		lang := adminLanguage(s, r)
+
+		all, err := s.GetDB().GetAllInvitationsWithResponses()
+		if err != nil {
+			http.Error(w, "Failed to load invitations", http.StatusInternalServerError)
+			return
+		}
+
+		selected := make(map[int64]bool, len(ids))
+		for _, id := range ids {
+			selected[id] = true
+		}
+		var invitations []*database.InvitationWithResponse
+		for _, inv := range all {
+			if selected[inv.ID] {
+				invitations = append(invitations, inv)
+			}
+		}
+
+		recordAudit(s, r, database.AuditExportCSV, 0, "", nil, map[string][]int64{"ids": ids})
+
+		writeCSV(w, lang, invitations)
+	}
+}
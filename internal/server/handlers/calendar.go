@@ -0,0 +1,118 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/AlexTLDR/evite/internal/calendar"
+	"github.com/AlexTLDR/evite/internal/config"
+	"github.com/AlexTLDR/evite/internal/i18n"
+)
+
+// newCalendarEvent builds the calendar event from the configured event details
+// If guestName is set, the description mentions who the invitation is for
+func newCalendarEvent(cfg *config.Config, guestName string, lang i18n.Language) calendar.Event {
+	host := "evite"
+	if u, err := url.Parse(cfg.BaseURL); err == nil && u.Host != "" {
+		host = u.Host
+	}
+
+	churchLabel, restaurantLabel, guestLabel := "Biserica", "Restaurant", "Invitație pentru"
+	if lang == i18n.English {
+		churchLabel, restaurantLabel, guestLabel = "Church", "Restaurant", "Invitation for"
+	}
+
+	var description []string
+	if guestName != "" {
+		description = append(description, fmt.Sprintf("%s %s", guestLabel, guestName))
+	}
+	if venue := formatVenue(cfg.ChurchName, cfg.ChurchAddress); venue != "" {
+		description = append(description, fmt.Sprintf("%s: %s", churchLabel, venue))
+	}
+	if venue := formatVenue(cfg.RestaurantName, cfg.RestaurantAddress); venue != "" {
+		description = append(description, fmt.Sprintf("%s: %s", restaurantLabel, venue))
+	}
+	description = append(description, cfg.BaseURL)
+
+	// The event starts at the church, fall back to the restaurant if no church is configured
+	location := formatVenue(cfg.ChurchName, cfg.ChurchAddress)
+	if location == "" {
+		location = formatVenue(cfg.RestaurantName, cfg.RestaurantAddress)
+	}
+
+	return calendar.Event{
+		UID:         fmt.Sprintf("event-%s@%s", cfg.EventDate.UTC().Format("20060102T150405Z"), host),
+		Summary:     cfg.EventName,
+		Description: strings.Join(description, "\n"),
+		Location:    location,
+		Start:       cfg.EventDate,
+		End:         cfg.EventDate.Add(cfg.EventDuration),
+	}
+}
+
+// formatVenue joins a venue name and address, skipping empty parts
+func formatVenue(name, address string) string {
+	var parts []string
+	for _, part := range []string{name, address} {
+		if part = strings.TrimSpace(part); part != "" {
+			parts = append(parts, part)
+		}
+	}
+	return strings.Join(parts, ", ")
+}
+
+// calendarLinks builds the "add to calendar" links shown after a positive RSVP
+func calendarLinks(cfg *config.Config, guestName, token string, lang i18n.Language) *calendar.Links {
+	event := newCalendarEvent(cfg, guestName, lang)
+
+	icsURL := "/calendar/event.ics?lang=" + string(lang)
+	if token != "" {
+		icsURL = "/calendar/" + url.PathEscape(token) + ".ics?lang=" + string(lang)
+	}
+
+	return &calendar.Links{
+		Google:  event.GoogleURL(),
+		Outlook: event.OutlookURL(),
+		ICS:     icsURL,
+	}
+}
+
+// writeICS writes an iCalendar file download response
+func writeICS(w http.ResponseWriter, event calendar.Event, filename string) {
+	w.Header().Set("Content-Type", "text/calendar; charset=utf-8")
+	w.Header().Set("Content-Disposition", "attachment; filename="+filename)
+	_, _ = w.Write([]byte(event.ICS(time.Now())))
+}
+
+// HandleCalendarEvent serves the generic event as an .ics file
+func HandleCalendarEvent(s Server) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		lang := i18n.GetLanguageFromRequest(r)
+		writeICS(w, newCalendarEvent(s.GetConfig(), "", lang), "event.ics")
+	}
+}
+
+// HandleCalendarInvitation serves the event as an .ics file personalized for an invitation
+// URL format: /calendar/{token}.ics
+func HandleCalendarInvitation(s Server) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		token := strings.TrimPrefix(r.URL.Path, "/calendar/")
+		if !strings.HasSuffix(token, ".ics") {
+			http.NotFound(w, r)
+			return
+		}
+		token = strings.TrimSuffix(token, ".ics")
+
+		invitation, err := s.GetDB().GetInvitationByToken(token)
+		if err != nil || invitationLinkStatus(s.GetConfig(), invitation, time.Now()) != "" {
+			http.NotFound(w, r)
+			return
+		}
+
+		lang := i18n.GetLanguageFromRequest(r)
+		writeICS(w, newCalendarEvent(s.GetConfig(), invitation.GuestName, lang), "invitation.ics")
+	}
+}
@@ -0,0 +1,227 @@
+package handlers
+
+import (
+	"archive/zip"
+	"bytes"
+	"fmt"
+	"image"
+	"image/png"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/AlexTLDR/evite/internal/cards"
+	"github.com/AlexTLDR/evite/internal/config"
+	"github.com/AlexTLDR/evite/internal/database"
+	"github.com/AlexTLDR/evite/internal/i18n"
+	"github.com/AlexTLDR/evite/templates"
+)
+
+// cardDesigns maps the card designs offered to admins to their background images in static/images
+var cardDesigns = map[string]string{
+	"card": "card.png",
+	"long": "long-card.png",
+}
+
+// loadCardBackground decodes the background image of a card design
+func loadCardBackground(design string) (image.Image, error) {
+	file, ok := cardDesigns[design]
+	if !ok {
+		return nil, fmt.Errorf("unknown card design %q", design)
+	}
+	f, err := os.Open(filepath.Join("static", "images", file))
+	if err != nil {
+		return nil, fmt.Errorf("failed to open card background: %w", err)
+	}
+	defer f.Close()
+
+	img, err := png.Decode(f)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode card background: %w", err)
+	}
+	return img, nil
+}
+
+// buildCard gathers the text printed on an invitation's card, in the guest's language
+func buildCard(cfg *config.Config, inv *database.Invitation, lang i18n.Language) cards.Card {
+	details := []string{formatDeadline(cfg.EventDate, lang)}
+	for _, venue := range [][2]string{{cfg.ChurchName, cfg.ChurchAddress}, {cfg.RestaurantName, cfg.RestaurantAddress}} {
+		if text := strings.Trim(venue[0]+", "+venue[1], ", "); text != "" {
+			details = append(details, text)
+		}
+	}
+
+	host := cfg.BaseURL
+	if u, err := url.Parse(cfg.BaseURL); err == nil && u.Host != "" {
+		host = u.Host
+	}
+
+	rsvpBy := "Please RSVP by %s"
+	footer := "Scan the QR code to respond"
+	if inv.Code != "" {
+		footer = fmt.Sprintf("Scan the QR code or enter the code %s at %s", inv.Code, host)
+	}
+	if lang == "ro" {
+		rsvpBy = "Vă rugăm să confirmați până la %s"
+		footer = "Scanați codul QR pentru a răspunde"
+		if inv.Code != "" {
+			footer = fmt.Sprintf("Scanați codul QR sau introduceți codul %s pe %s", inv.Code, host)
+		}
+	}
+	details = append(details, fmt.Sprintf(rsvpBy, formatDeadline(cfg.RSVPDeadline, lang)))
+
+	return cards.Card{
+		GuestName: inv.GuestName,
+		Title:     cfg.EventName,
+		Details:   details,
+		Link:      rsvpURL(cfg, inv.Token),
+		Footer:    []string{footer},
+	}
+}
+
+// renderCardPNG renders an invitation's card as PNG
+func renderCardPNG(cfg *config.Config, background image.Image, inv *database.Invitation, lang i18n.Language) ([]byte, error) {
+	img, err := cards.Render(background, buildCard(cfg, inv, lang))
+	if err != nil {
+		return nil, err
+	}
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		return nil, fmt.Errorf("failed to encode card: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// selectedInvitations returns the invitations with the given IDs, or all invitations with a working link when none are given
+func selectedInvitations(db *database.DB, idStrs []string) ([]*database.Invitation, error) {
+	if len(idStrs) == 0 {
+		all, err := db.GetAllInvitations()
+		if err != nil {
+			return nil, err
+		}
+		var invitations []*database.Invitation
+		for _, inv := range all {
+			if !inv.RevokedAt.Valid {
+				invitations = append(invitations, inv)
+			}
+		}
+		return invitations, nil
+	}
+
+	invitations := make([]*database.Invitation, 0, len(idStrs))
+	for _, idStr := range idStrs {
+		id, err := parseID(idStr)
+		if err != nil {
+			return nil, err
+		}
+		inv, err := db.GetInvitationByID(id)
+		if err != nil {
+			return nil, err
+		}
+		if inv.DeletedAt.Valid {
+			continue
+		}
+		invitations = append(invitations, inv)
+	}
+	return invitations, nil
+}
+
+// HandleAdminCards shows the form for exporting printable invitation cards
+func HandleAdminCards(s AdminServer) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		_, userName := s.GetCurrentUser(r)
+		lang := adminLanguage(s, r)
+
+		invitations, err := selectedInvitations(s.GetDB(), nil)
+		if err != nil {
+			http.Error(w, "Failed to load invitations", http.StatusInternalServerError)
+			return
+		}
+
+		themes := config.GetThemes()
+		if err := templates.AdminCards(string(lang), userName, invitations, themes.Light, themes.Dark).Render(r.Context(), w); err != nil {
+			http.Error(w, "Failed to render page", http.StatusInternalServerError)
+		}
+	}
+}
+
+// HandleAdminDownloadCards renders printable cards for the selected invitations (all when none are selected)
+// A single card is served as PNG, several as a ZIP of PNGs
+func HandleAdminDownloadCards(s AdminServer) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+
+		design := query.Get("design")
+		if _, ok := cardDesigns[design]; !ok {
+			design = "card"
+		}
+		cardLang, ok := i18n.ParseLanguage(query.Get("card_lang"))
+		if !ok {
+			cardLang = i18n.Romanian
+		}
+
+		invitations, err := selectedInvitations(s.GetDB(), query["id"])
+		if err != nil {
+			http.Error(w, "Invitation not found", http.StatusNotFound)
+			return
+		}
+		if len(invitations) == 0 {
+			http.Redirect(w, r, "/admin/cards", http.StatusSeeOther)
+			return
+		}
+
+		background, err := loadCardBackground(design)
+		if err != nil {
+			fmt.Printf("Warning: %v\n", err)
+			http.Error(w, "Failed to load card design", http.StatusInternalServerError)
+			return
+		}
+
+		recordAudit(s, r, database.AuditExportCards, 0, "", nil, nil)
+
+		if len(invitations) == 1 {
+			inv := invitations[0]
+			data, err := renderCardPNG(s.GetConfig(), background, inv, cardLang)
+			if err != nil {
+				http.Error(w, "Failed to render card", http.StatusInternalServerError)
+				return
+			}
+			w.Header().Set("Content-Type", "image/png")
+			w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", cardFileName(inv)))
+			w.Write(data)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/zip")
+		w.Header().Set("Content-Disposition", "attachment; filename=invitation-cards.zip")
+
+		archive := zip.NewWriter(w)
+		for _, inv := range invitations {
+			data, err := renderCardPNG(s.GetConfig(), background, inv, cardLang)
+			if err != nil {
+				fmt.Printf("Warning: failed to render card for invitation %d: %v\n", inv.ID, err)
+				continue
+			}
+
+			f, err := archive.Create(cardFileName(inv))
+			if err != nil {
+				fmt.Printf("Warning: failed to add card to archive: %v\n", err)
+				return
+			}
+			if _, err := f.Write(data); err != nil {
+				fmt.Printf("Warning: failed to write card to archive: %v\n", err)
+				return
+			}
+		}
+		if err := archive.Close(); err != nil {
+			fmt.Printf("Warning: failed to finish card archive: %v\n", err)
+		}
+	}
+}
+
+// cardFileName builds the file name of an invitation's card, e.g. "card-ion-popescu-ABC234.png"
+func cardFileName(inv *database.Invitation) string {
+	return "card-" + qrFileName(inv, "png")
+}
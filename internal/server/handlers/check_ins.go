@@ -0,0 +1,81 @@
+package handlers
+
+import (
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/AlexTLDR/evite/internal/config"
+	"github.com/AlexTLDR/evite/internal/database"
+	"github.com/AlexTLDR/evite/templates"
+)
+
+// HandleAdminCheckIn lists the attending guests for checking them in at the entrance
+func HandleAdminCheckIn(s AdminServer) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		_, userName := s.GetCurrentUser(r)
+		lang := adminLanguage(s, r)
+
+		search := strings.TrimSpace(r.URL.Query().Get("q"))
+		guests, err := s.GetDB().GetCheckInGuests(search)
+		if err != nil {
+			http.Error(w, "Failed to load guests", http.StatusInternalServerError)
+			return
+		}
+
+		themes := config.GetThemes()
+		if err := templates.AdminCheckIn(string(lang), userName, guests, search, themes.Light, themes.Dark).Render(r.Context(), w); err != nil {
+			http.Error(w, "Failed to render page", http.StatusInternalServerError)
+		}
+	}
+}
+
+// HandleAdminCheckInGuest checks an invitation's guests in, or undoes the check-in
+func HandleAdminCheckInGuest(s AdminServer) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			http.Redirect(w, r, "/admin/checkin", http.StatusSeeOther)
+			return
+		}
+		if err := r.ParseForm(); err != nil {
+			http.Error(w, "Invalid form", http.StatusBadRequest)
+			return
+		}
+		id, err := parseID(r.FormValue("id"))
+		if err != nil {
+			http.Error(w, "Invalid invitation ID", http.StatusBadRequest)
+			return
+		}
+
+		invitation, err := s.GetDB().GetInvitationByID(id)
+		if err != nil || invitation.DeletedAt.Valid {
+			http.Error(w, "Invitation not found", http.StatusNotFound)
+			return
+		}
+
+		email, _ := s.GetCurrentUser(r)
+		action := database.AuditInvitationCheckIn
+		var changed bool
+		if r.FormValue("undo") == "1" {
+			action = database.AuditInvitationUndoCheckIn
+			changed, err = s.GetDB().UndoCheckIn(id)
+		} else {
+			changed, err = s.GetDB().CheckIn(id, email)
+		}
+		if err != nil {
+			http.Error(w, "Failed to update check-in", http.StatusInternalServerError)
+			return
+		}
+
+		// Another device may have checked the guest in already; only record actual changes
+		if changed {
+			recordAudit(s, r, action, id, invitation.GuestName, nil, nil)
+		}
+
+		redirect := "/admin/checkin"
+		if search := strings.TrimSpace(r.FormValue("q")); search != "" {
+			redirect += "?q=" + url.QueryEscape(search)
+		}
+		http.Redirect(w, r, redirect, http.StatusSeeOther)
+	}
+}
@@ -7,12 +7,14 @@ import (
 	"strings"
 
 	"github.com/AlexTLDR/evite/internal/database"
+	"github.com/AlexTLDR/evite/internal/i18n"
 )
 
 // csvRowData holds formatted data for a single CSV row
 type csvRowData struct {
 	name                    string
 	phone                   string
+	code                    string
 	sent                    string
 	opened                  string
 	responded               string
@@ -33,12 +35,12 @@ func escapeCSVField(field string) string {
 	return escaped
 }
 
-// formatYesNo converts a boolean to "Da"/"Nu"
-func formatYesNo(value bool) string {
+// formatYesNo converts a boolean to a localized yes/no ("Da"/"Nu" in Romanian)
+func formatYesNo(value bool, lang i18n.Language) string {
 	if value {
-		return "Da"
+		return i18n.T(lang, "common.yes")
 	}
-	return "Nu"
+	return i18n.T(lang, "common.no")
 }
 
 // formatNullableString returns the string value or a default if null/empty
@@ -50,15 +52,15 @@ func formatNullableString(ns sql.NullString, defaultValue string) string {
 }
 
 // formatResponseData extracts and formats all response fields
-func formatResponseData(response *database.Response) (attending, plusOne, kidsCount, menuPref, companionMenuPref, comment string) {
+func formatResponseData(response *database.Response, lang i18n.Language) (attending, plusOne, kidsCount, menuPref, companionMenuPref, comment string) {
 	// Default values when no response
 	if response == nil {
 		return "-", "-", "-", "-", "-", "-"
 	}
 
 	// Format boolean fields
-	attending = formatYesNo(response.Attending)
-	plusOne = formatYesNo(response.PlusOne)
+	attending = formatYesNo(response.Attending, lang)
+	plusOne = formatYesNo(response.PlusOne, lang)
 
 	// Format kids count
 	if response.KidsCount > 0 {
@@ -82,33 +84,40 @@ func formatResponseData(response *database.Response) (attending, plusOne, kidsCo
 }
 
 // formatInvitationForCSV converts an invitation to CSV row data
-func formatInvitationForCSV(inv *database.InvitationWithResponse) csvRowData {
+func formatInvitationForCSV(inv *database.InvitationWithResponse, lang i18n.Language) csvRowData {
 	row := csvRowData{
 		name:      escapeCSVField(inv.GuestName),
 		phone:     escapeCSVField(inv.Phone),
-		sent:      formatYesNo(inv.SentAt.Valid),
-		opened:    formatYesNo(inv.OpenedAt.Valid),
-		responded: formatYesNo(inv.RespondedAt.Valid),
+		code:      inv.Code,
+		sent:      formatYesNo(inv.SentAt.Valid, lang),
+		opened:    formatYesNo(inv.OpenedAt.Valid, lang),
+		responded: formatYesNo(inv.RespondedAt.Valid, lang),
 	}
 
 	// Format response data
 	row.attending, row.plusOne, row.kidsCount,
 		row.menuPreference, row.companionMenuPreference,
-		row.comment = formatResponseData(inv.Response)
+		row.comment = formatResponseData(inv.Response, lang)
 
 	return row
 }
 
 // buildCSVRow creates a CSV line from row data
 func buildCSVRow(row csvRowData) string {
-	return fmt.Sprintf("\"%s\",\"%s\",\"%s\",\"%s\",\"%s\",\"%s\",\"%s\",\"%s\",\"%s\",\"%s\",\"%s\"\n",
-		row.name, row.phone, row.sent, row.opened, row.responded,
+	return fmt.Sprintf("\"%s\",\"%s\",\"%s\",\"%s\",\"%s\",\"%s\",\"%s\",\"%s\",\"%s\",\"%s\",\"%s\",\"%s\"\n",
+		row.name, row.phone, row.code, row.sent, row.opened, row.responded,
 		row.attending, row.plusOne, row.kidsCount,
 		row.menuPreference, row.companionMenuPreference, row.comment)
 }
 
+// csvHeaderKeys lists the translation keys of the CSV header row, in column order
+var csvHeaderKeys = []string{
+	"csv.name", "csv.phone", "csv.code", "csv.sent", "csv.opened", "csv.responded",
+	"csv.attending", "csv.plus_one", "csv.kids", "csv.menu", "csv.companion_menu", "csv.comment",
+}
+
 // writeCSVHeaders sets HTTP headers and writes CSV header row
-func writeCSVHeaders(w http.ResponseWriter) {
+func writeCSVHeaders(w http.ResponseWriter, lang i18n.Language) {
 	// Set CSV headers
 	w.Header().Set("Content-Type", "text/csv; charset=utf-8")
 	w.Header().Set("Content-Disposition", "attachment; filename=rsvp-list.csv")
@@ -117,26 +126,39 @@ func writeCSVHeaders(w http.ResponseWriter) {
 	w.Write([]byte{0xEF, 0xBB, 0xBF})
 
 	// Write CSV header
-	w.Write([]byte("Nume,Telefon,Trimis,Deschis,Răspuns,Participă,Plus 1,Copii,Meniu,Meniu Însoțitor,Mesaj\n"))
+	headers := make([]string, len(csvHeaderKeys))
+	for i, key := range csvHeaderKeys {
+		headers[i] = i18n.T(lang, key)
+	}
+	w.Write([]byte(strings.Join(headers, ",") + "\n"))
 }
 
 // HandleAdminDownloadCSV exports invitations to CSV
-func HandleAdminDownloadCSV(s Server) http.HandlerFunc {
+func HandleAdminDownloadCSV(s AdminServer) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
+		lang := adminLanguage(s, r)
+
 		invitations, err := s.GetDB().GetAllInvitationsWithResponses()
 		if err != nil {
 			http.Error(w, "Failed to load invitations", http.StatusInternalServerError)
 			return
 		}
 
-		// Write CSV headers
-		writeCSVHeaders(w)
+		recordAudit(s, r, database.AuditExportCSV, 0, "", nil, nil)
 
-		// Write data rows
-		for _, inv := range invitations {
-			row := formatInvitationForCSV(inv)
-			line := buildCSVRow(row)
-			w.Write([]byte(line))
-		}
+		writeCSV(w, lang, invitations)
+	}
+}
+
+// writeCSV writes the CSV export of the given invitations
+func writeCSV(w http.ResponseWriter, lang i18n.Language, invitations []*database.InvitationWithResponse) {
+	// Write CSV headers
+	writeCSVHeaders(w, lang)
+
+	// Write data rows
+	for _, inv := range invitations {
+		row := formatInvitationForCSV(inv, lang)
+		line := buildCSVRow(row)
+		w.Write([]byte(line))
 	}
 }
@@ -0,0 +1,204 @@
+package handlers
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"image/png"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/AlexTLDR/evite/internal/cards"
+	"github.com/AlexTLDR/evite/internal/config"
+	"github.com/AlexTLDR/evite/internal/database"
+	"github.com/AlexTLDR/evite/internal/i18n"
+	"github.com/AlexTLDR/evite/templates"
+)
+
+// previewImageMaxAge is how long crawlers may cache link preview images,
+// and how long rendered images are kept in memory
+const previewImageMaxAge = time.Hour
+
+// loadPreviewBackground decodes the link preview background once
+var loadPreviewBackground = sync.OnceValues(func() (image.Image, error) {
+	return loadCardBackground("card")
+})
+
+// previewImage is a rendered link preview image
+type previewImage struct {
+	png     []byte
+	expires time.Time
+}
+
+// previewImages caches rendered link preview images so that crawlers fetching the same link
+// do not render it again; keys include the guest name so that renamed guests get a new image
+var previewImages = struct {
+	sync.Mutex
+	byKey map[string]previewImage
+}{byKey: make(map[string]previewImage)}
+
+// renderPreviewImage returns the PNG preview image of an invitation (nil for the generic event image)
+// in the given language, rendering it only when it is not cached yet
+func renderPreviewImage(cfg *config.Config, invitation *database.Invitation, lang i18n.Language) ([]byte, error) {
+	key := "event\x00" + string(lang)
+	if invitation != nil {
+		key = invitation.Token + "\x00" + string(lang) + "\x00" + invitation.GuestName
+	}
+	now := time.Now()
+
+	previewImages.Lock()
+	cached, ok := previewImages.byKey[key]
+	previewImages.Unlock()
+	if ok && now.Before(cached.expires) {
+		return cached.png, nil
+	}
+
+	background, err := loadPreviewBackground()
+	if err != nil {
+		return nil, err
+	}
+	img, err := cards.RenderPreview(background, buildPreview(cfg, invitation, lang))
+	if err != nil {
+		return nil, fmt.Errorf("failed to render preview: %w", err)
+	}
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		return nil, fmt.Errorf("failed to encode preview: %w", err)
+	}
+
+	previewImages.Lock()
+	defer previewImages.Unlock()
+	for k, entry := range previewImages.byKey {
+		if !now.Before(entry.expires) {
+			delete(previewImages.byKey, k)
+		}
+	}
+	previewImages.byKey[key] = previewImage{png: buf.Bytes(), expires: now.Add(previewImageMaxAge)}
+	return buf.Bytes(), nil
+}
+
+// previewInvitation returns the invitation a link preview is for, or nil for unknown, revoked and expired tokens
+// so that previews of those links show only the public event details
+// Unlike loadInvitationByToken it does not mark the invitation as opened: crawlers are not guests
+func previewInvitation(s Server, token string) *database.Invitation {
+	if token == "" {
+		return nil
+	}
+	invitation, err := s.GetDB().GetInvitationByToken(token)
+	if err != nil || invitationLinkStatus(s.GetConfig(), invitation, time.Now()) != "" {
+		return nil
+	}
+	markAsPreviewed(s, invitation)
+	return invitation
+}
+
+// markAsPreviewed records the first time a crawler or prefetcher fetched an invitation link
+func markAsPreviewed(s Server, invitation *database.Invitation) {
+	if invitation.PreviewedAt.Valid {
+		return
+	}
+	if err := s.GetDB().MarkAsPreviewed(invitation.ID); err != nil {
+		// Log but don't fail - this is just tracking
+		fmt.Printf("Warning: failed to mark invitation as previewed: %v\n", err)
+	}
+}
+
+// linkPreviewText returns the title and description shown in the preview of an RSVP link
+func linkPreviewText(cfg *config.Config, invitation *database.Invitation, lang i18n.Language) (string, string) {
+	title := cfg.EventName
+	description := fmt.Sprintf("You are invited to %s on %s. Please RSVP by %s.",
+		cfg.EventName, formatDeadline(cfg.EventDate, lang), formatDeadline(cfg.RSVPDeadline, lang))
+	if lang == "ro" {
+		description = fmt.Sprintf("Vă invităm la %s pe %s. Vă rugăm să confirmați prezența până la %s.",
+			cfg.EventName, formatDeadline(cfg.EventDate, lang), formatDeadline(cfg.RSVPDeadline, lang))
+	}
+
+	if invitation != nil {
+		title = fmt.Sprintf("Invitation for %s - %s", invitation.GuestName, cfg.EventName)
+		if lang == "ro" {
+			title = fmt.Sprintf("Invitație pentru %s - %s", invitation.GuestName, cfg.EventName)
+		}
+	}
+	return title, description
+}
+
+// buildPreview gathers the text drawn on a link preview image
+func buildPreview(cfg *config.Config, invitation *database.Invitation, lang i18n.Language) cards.Preview {
+	rsvpBy := "Please RSVP by %s"
+	if lang == "ro" {
+		rsvpBy = "Confirmați până la %s"
+	}
+	preview := cards.Preview{
+		Title: cfg.EventName,
+		Lines: []string{formatDeadline(cfg.EventDate, lang), fmt.Sprintf(rsvpBy, formatDeadline(cfg.RSVPDeadline, lang))},
+	}
+
+	if invitation != nil {
+		preview.Title = "Invitation for " + invitation.GuestName
+		if lang == "ro" {
+			preview.Title = "Invitație pentru " + invitation.GuestName
+		}
+		preview.Subtitle = cfg.EventName
+	}
+	return preview
+}
+
+// renderLinkPreview serves the Open Graph page link preview crawlers read for an RSVP link
+func renderLinkPreview(s Server, w http.ResponseWriter, r *http.Request, token string) {
+	cfg := s.GetConfig()
+	lang := i18n.GetLanguageFromRequest(r)
+	invitation := previewInvitation(s, token)
+
+	title, description := linkPreviewText(cfg, invitation, lang)
+	imageName := "event"
+	if invitation != nil {
+		imageName = url.PathEscape(invitation.Token)
+	}
+	imageURL := fmt.Sprintf("%s/og/%s.png?lang=%s", cfg.BaseURL, imageName, lang)
+	pageURL := rsvpURL(cfg, url.PathEscape(token))
+	redirectURL := "/?token=" + url.QueryEscape(token)
+
+	if err := templates.LinkPreview(string(lang), title, description, imageURL, pageURL, redirectURL).Render(r.Context(), w); err != nil {
+		http.Error(w, "Failed to render page", http.StatusInternalServerError)
+	}
+}
+
+// HandleLinkPreviewImage serves the image shown in the preview of an RSVP link
+// URL format: /og/{token}.png, or /og/event.png for the generic event image
+func HandleLinkPreviewImage(s Server) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		name := strings.TrimPrefix(r.URL.Path, "/og/")
+		if !strings.HasSuffix(name, ".png") {
+			http.NotFound(w, r)
+			return
+		}
+		token := strings.TrimSuffix(name, ".png")
+
+		// Only the generic image and valid invitation links have a preview image
+		var invitation *database.Invitation
+		if token != "event" {
+			if invitation = previewInvitation(s, token); invitation == nil {
+				http.NotFound(w, r)
+				return
+			}
+		}
+
+		img, err := renderPreviewImage(s.GetConfig(), invitation, i18n.GetLanguageFromRequest(r))
+		if err != nil {
+			fmt.Printf("Warning: %v\n", err)
+			http.Error(w, "Failed to render preview", http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "image/png")
+		w.Header().Set("Content-Length", strconv.Itoa(len(img)))
+		w.Header().Set("Cache-Control", fmt.Sprintf("public, max-age=%d", int(previewImageMaxAge.Seconds())))
+		if _, err := w.Write(img); err != nil {
+			fmt.Printf("Warning: failed to write preview image: %v\n", err)
+		}
+	}
+}
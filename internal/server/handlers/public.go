@@ -1,13 +1,16 @@
 package handlers
 
 import (
+	"bytes"
 	"fmt"
 	"net/http"
 	"time"
 
+	"github.com/AlexTLDR/evite/internal/calendar"
 	"github.com/AlexTLDR/evite/internal/config"
 	"github.com/AlexTLDR/evite/internal/database"
 	"github.com/AlexTLDR/evite/internal/i18n"
+	"github.com/AlexTLDR/evite/internal/utils"
 	"github.com/AlexTLDR/evite/templates"
 )
 
@@ -17,25 +20,59 @@ type Server interface {
 	GetConfig() *config.Config
 }
 
+// Reasons an invitation link no longer works, shown to the guest on the home page
+const (
+	linkRevoked = "revoked"
+	linkExpired = "expired"
+)
+
 // homePageData holds all data needed to render the home page
 type homePageData struct {
 	lang           string
 	lightTheme     string
 	darkTheme      string
 	invitation     *database.Invitation
+	visitID        int64
+	response       *database.Response
+	linkStatus     string
+	codeError      bool
 	deadlinePassed bool
 	deadlineText   string
+	calendarLinks  *calendar.Links
+}
+
+// invitationLinkStatus reports why an invitation's link no longer works ("" when it still does)
+func invitationLinkStatus(cfg *config.Config, invitation *database.Invitation, now time.Time) string {
+	if invitation.RevokedAt.Valid {
+		return linkRevoked
+	}
+	if cfg.LinkExpiryAfterEvent > 0 && now.After(cfg.EventDate.Add(cfg.EventDuration+cfg.LinkExpiryAfterEvent)) {
+		return linkExpired
+	}
+	return ""
 }
 
 // loadInvitationByToken loads an invitation by token and marks it as opened and sent
-func loadInvitationByToken(db *database.DB, token string) *database.Invitation {
+// Link preview crawlers and prefetches are recorded as previews instead, so that "opened" means a person saw it
+// Revoked and expired links return no invitation, only the reason they stopped working
+func loadInvitationByToken(s Server, r *http.Request, token string) (*database.Invitation, string) {
 	if token == "" {
-		return nil
+		return nil, ""
 	}
 
+	db := s.GetDB()
 	invitation, err := db.GetInvitationByToken(token)
 	if err != nil {
-		return nil
+		return nil, ""
+	}
+
+	if status := invitationLinkStatus(s.GetConfig(), invitation, time.Now()); status != "" {
+		return nil, status
+	}
+
+	if utils.IsAutomatedVisit(r) {
+		markAsPreviewed(s, invitation)
+		return invitation, ""
 	}
 
 	// Mark as sent if not already (if they opened it, it was sent)
@@ -54,7 +91,41 @@ func loadInvitationByToken(db *database.DB, token string) *database.Invitation {
 		}
 	}
 
-	return invitation
+	return invitation, ""
+}
+
+// recordVisit stores a guest's visit to their invitation page and returns its ID (0 when nothing was recorded)
+// Crawlers, prefetches and the redirects after submitting or withdrawing a response are not visits
+func recordVisit(s Server, r *http.Request, invitation *database.Invitation, lang i18n.Language) int64 {
+	query := r.URL.Query()
+	if invitation == nil || utils.IsAutomatedVisit(r) || query.Get("submitted") != "" || query.Get("withdrawn") != "" {
+		return 0
+	}
+	visit, err := s.GetDB().CreateVisit(invitation.ID, string(lang), utils.DeviceClass(r.UserAgent()))
+	if err != nil {
+		// Log but don't fail - this is just tracking
+		fmt.Printf("Warning: failed to record visit: %v\n", err)
+		return 0
+	}
+	return visit.ID
+}
+
+// latestResponse returns the invitation's current response, or nil when there is none
+func latestResponse(s Server, invitation *database.Invitation) *database.Response {
+	if invitation == nil {
+		return nil
+	}
+	response, err := s.GetDB().GetLatestResponseByInvitationID(invitation.ID)
+	if err != nil {
+		fmt.Printf("Warning: failed to load latest response: %v\n", err)
+		return nil
+	}
+	return response
+}
+
+// rsvpURL returns the public RSVP link for an invitation token
+func rsvpURL(cfg *config.Config, token string) string {
+	return fmt.Sprintf("%s/rsvp/%s", cfg.BaseURL, token)
 }
 
 // checkDeadlinePassed checks if the RSVP deadline has passed with debug logging
@@ -90,14 +161,33 @@ func prepareHomePageData(s Server, r *http.Request) homePageData {
 	lang := i18n.GetLanguageFromRequest(r)
 	themes := config.GetThemes()
 	token := r.URL.Query().Get("token")
+	invitation, linkStatus := loadInvitationByToken(s, r, token)
+	response := latestResponse(s, invitation)
+
+	// Offer "add to calendar" links right after an RSVP when the guest's stored response is attending
+	// Responses sent without an invitation link only get the public event, as they cannot be looked up
+	var links *calendar.Links
+	if r.URL.Query().Get("submitted") == "true" {
+		switch {
+		case invitation != nil && response != nil && response.Attending:
+			links = calendarLinks(s.GetConfig(), invitation.GuestName, invitation.Token, lang)
+		case invitation == nil && r.URL.Query().Get("attending") == "yes":
+			links = calendarLinks(s.GetConfig(), "", "", lang)
+		}
+	}
 
 	return homePageData{
 		lang:           string(lang),
 		lightTheme:     themes.Light,
 		darkTheme:      themes.Dark,
-		invitation:     loadInvitationByToken(s.GetDB(), token),
+		invitation:     invitation,
+		visitID:        recordVisit(s, r, invitation, lang),
+		response:       response,
+		linkStatus:     linkStatus,
+		codeError:      r.URL.Query().Get("code") == "invalid",
 		deadlinePassed: checkDeadlinePassed(s.GetConfig()),
 		deadlineText:   formatDeadline(s.GetConfig().RSVPDeadline, lang),
+		calendarLinks:  links,
 	}
 }
 
@@ -106,12 +196,46 @@ func HandleHome(s Server) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		data := prepareHomePageData(s, r)
 
-		if err := templates.Home(data.lang, data.lightTheme, data.darkTheme, data.invitation, data.deadlinePassed, data.deadlineText).Render(r.Context(), w); err != nil {
+		if err := templates.Home(data.lang, data.lightTheme, data.darkTheme, data.invitation, data.response, data.visitID, data.linkStatus, data.codeError, data.deadlinePassed, data.deadlineText, data.calendarLinks, nil).Render(r.Context(), w); err != nil {
 			http.Error(w, "Failed to render page", http.StatusInternalServerError)
 		}
 	}
 }
 
+// HandleNotFound renders the not-found page for paths that match no route
+// It is not rate limited, so stray requests (favicons, scanners) do not use up a guest's budget
+func HandleNotFound(s Server) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		lang := i18n.GetLanguageFromRequest(r)
+		themes := config.GetThemes()
+
+		// Render first so that a render error can still be answered with its own status
+		var buf bytes.Buffer
+		if err := templates.NotFound(string(lang), themes.Light, themes.Dark).Render(r.Context(), &buf); err != nil {
+			http.Error(w, "Failed to render page", http.StatusInternalServerError)
+			return
+		}
+		w.WriteHeader(http.StatusNotFound)
+		buf.WriteTo(w)
+	}
+}
+
+// HandleInviteCode resolves a short invitation code to the invitation's page
+func HandleInviteCode(s Server) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		lang := i18n.GetLanguageFromRequest(r)
+
+		if code := utils.NormalizeInviteCode(r.FormValue("code")); code != "" {
+			if invitation, err := s.GetDB().GetInvitationByCode(code); err == nil {
+				http.Redirect(w, r, "/?token="+invitation.Token+"&lang="+string(lang), http.StatusSeeOther)
+				return
+			}
+		}
+
+		http.Redirect(w, r, "/?code=invalid&lang="+string(lang), http.StatusSeeOther)
+	}
+}
+
 // HandleRSVP redirects RSVP links to home page with token
 func HandleRSVP(s Server) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
@@ -123,6 +247,12 @@ func HandleRSVP(s Server) http.HandlerFunc {
 			return
 		}
 
+		// Link preview crawlers get the Open Graph page; they must not mark the invitation as opened
+		if utils.IsLinkPreviewBot(r.UserAgent()) {
+			renderLinkPreview(s, w, r, token)
+			return
+		}
+
 		// Redirect to home page with token as query parameter
 		http.Redirect(w, r, "/?token="+token, http.StatusSeeOther)
 	}
@@ -0,0 +1,131 @@
+package handlers
+
+import (
+	"archive/zip"
+	"fmt"
+	"net/http"
+	"path"
+	"strings"
+
+	"github.com/AlexTLDR/evite/internal/database"
+	"github.com/AlexTLDR/evite/internal/qr"
+	"github.com/AlexTLDR/evite/internal/utils"
+)
+
+// qrPNGSize is the width and height of generated QR code PNGs, large enough to print sharply
+const qrPNGSize = 512
+
+// renderInvitationQR renders the QR code of an invitation's RSVP link as "png" or "svg"
+func renderInvitationQR(s Server, inv *database.Invitation, format string) ([]byte, error) {
+	link := rsvpURL(s.GetConfig(), inv.Token)
+	if format == "svg" {
+		return qr.SVG(link)
+	}
+	return qr.PNG(link, qrPNGSize)
+}
+
+// qrFileName builds a readable, unique file name for an invitation's QR code
+func qrFileName(inv *database.Invitation, format string) string {
+	name := utils.Slugify(inv.GuestName)
+	if name == "" {
+		name = "invitation"
+	}
+	suffix := inv.Code
+	if suffix == "" {
+		suffix = fmt.Sprintf("%d", inv.ID)
+	}
+	return fmt.Sprintf("%s-%s.%s", name, suffix, format)
+}
+
+// qrContentType returns the MIME type of a QR code format
+func qrContentType(format string) string {
+	if format == "svg" {
+		return "image/svg+xml"
+	}
+	return "image/png"
+}
+
+// HandleAdminInvitationQR serves the QR code of an invitation's RSVP link
+// URL format: /admin/invitations/qr/{id}.png or /admin/invitations/qr/{id}.svg
+func HandleAdminInvitationQR(s AdminServer) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		file := strings.TrimPrefix(r.URL.Path, "/admin/invitations/qr/")
+		format := strings.TrimPrefix(path.Ext(file), ".")
+		if format != "png" && format != "svg" {
+			http.NotFound(w, r)
+			return
+		}
+
+		id, err := parseID(strings.TrimSuffix(file, path.Ext(file)))
+		if err != nil {
+			http.Error(w, "Invalid invitation ID", http.StatusBadRequest)
+			return
+		}
+
+		inv, err := s.GetDB().GetInvitationByID(id)
+		if err != nil || inv.DeletedAt.Valid {
+			http.Error(w, "Invitation not found", http.StatusNotFound)
+			return
+		}
+
+		data, err := renderInvitationQR(s, inv, format)
+		if err != nil {
+			http.Error(w, "Failed to generate QR code", http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", qrContentType(format))
+		if r.URL.Query().Get("download") != "" {
+			w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", qrFileName(inv, format)))
+		}
+		w.Write(data)
+	}
+}
+
+// HandleAdminDownloadQRZip exports the QR codes of all invitations with a working link as a ZIP
+// The format query parameter selects png (default) or svg
+func HandleAdminDownloadQRZip(s AdminServer) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		format := r.URL.Query().Get("format")
+		if format != "svg" {
+			format = "png"
+		}
+
+		invitations, err := s.GetDB().GetAllInvitations()
+		if err != nil {
+			http.Error(w, "Failed to load invitations", http.StatusInternalServerError)
+			return
+		}
+
+		recordAudit(s, r, database.AuditExportQRCodes, 0, "", nil, nil)
+
+		w.Header().Set("Content-Type", "application/zip")
+		w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=qr-codes-%s.zip", format))
+
+		archive := zip.NewWriter(w)
+		for _, inv := range invitations {
+			if inv.RevokedAt.Valid {
+				continue
+			}
+
+			data, err := renderInvitationQR(s, inv, format)
+			if err != nil {
+				fmt.Printf("Warning: failed to generate QR code for invitation %d: %v\n", inv.ID, err)
+				continue
+			}
+
+			f, err := archive.Create(qrFileName(inv, format))
+			if err != nil {
+				fmt.Printf("Warning: failed to add QR code to archive: %v\n", err)
+				return
+			}
+			if _, err := f.Write(data); err != nil {
+				fmt.Printf("Warning: failed to write QR code to archive: %v\n", err)
+				return
+			}
+		}
+		if err := archive.Close(); err != nil {
+			fmt.Printf("Warning: failed to finish QR code archive: %v\n", err)
+		}
+	}
+}
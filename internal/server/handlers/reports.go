@@ -0,0 +1,62 @@
+package handlers
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/AlexTLDR/evite/internal/config"
+	"github.com/AlexTLDR/evite/internal/reports"
+	"github.com/AlexTLDR/evite/templates"
+)
+
+// loadCateringReport computes the catering report from the latest responses
+func loadCateringReport(s Server) (*reports.Catering, error) {
+	counts, err := s.GetDB().GetCateringCounts()
+	if err != nil {
+		return nil, err
+	}
+	return reports.NewCatering(counts, s.GetConfig()), nil
+}
+
+// HandleAdminCateringReport renders the catering report with per-menu headcounts and costs
+func HandleAdminCateringReport(s AdminServer) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		_, userName := s.GetCurrentUser(r)
+		lang := adminLanguage(s, r)
+
+		report, err := loadCateringReport(s)
+		if err != nil {
+			http.Error(w, "Failed to load catering report", http.StatusInternalServerError)
+			return
+		}
+
+		themes := config.GetThemes()
+		if err := templates.AdminCatering(string(lang), userName, report, themes.Light, themes.Dark).Render(r.Context(), w); err != nil {
+			http.Error(w, "Failed to render page", http.StatusInternalServerError)
+		}
+	}
+}
+
+// HandleAdminCateringPrint renders a printable version of the catering report for the venue
+// The browser's print dialog is used to save it as PDF
+func HandleAdminCateringPrint(s AdminServer) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		lang := adminLanguage(s, r)
+		cfg := s.GetConfig()
+
+		report, err := loadCateringReport(s)
+		if err != nil {
+			http.Error(w, "Failed to load catering report", http.StatusInternalServerError)
+			return
+		}
+
+		venue := formatVenue(cfg.RestaurantName, cfg.RestaurantAddress)
+		eventDate := formatDeadline(cfg.EventDate, lang)
+		generatedAt := formatDeadline(time.Now().In(cfg.EventDate.Location()), lang)
+
+		themes := config.GetThemes()
+		if err := templates.CateringPrint(string(lang), cfg.EventName, venue, eventDate, generatedAt, report, themes.Light, themes.Dark).Render(r.Context(), w); err != nil {
+			http.Error(w, "Failed to render page", http.StatusInternalServerError)
+		}
+	}
+}
@@ -1,11 +1,15 @@
 package handlers
 
 import (
+	"database/sql"
+	"errors"
 	"fmt"
 	"net/http"
+	"strconv"
 	"strings"
 	"time"
 
+	"github.com/AlexTLDR/evite/internal/database"
 	"github.com/AlexTLDR/evite/internal/i18n"
 	"github.com/AlexTLDR/evite/internal/utils"
 )
@@ -23,6 +27,23 @@ type rsvpFormData struct {
 	comment                 string
 }
 
+// honeypotField is a form field hidden from people that only bots fill in
+const honeypotField = "website"
+
+// recordHoneypot logs a submission that filled in the honeypot field
+func recordHoneypot(s Server, r *http.Request) {
+	event := &database.AbuseEvent{
+		Kind:      database.AbuseHoneypot,
+		Key:       sql.NullString{String: r.FormValue("token"), Valid: r.FormValue("token") != ""},
+		IP:        sql.NullString{String: utils.ClientIP(r), Valid: true},
+		Path:      sql.NullString{String: r.URL.Path, Valid: true},
+		UserAgent: sql.NullString{String: r.UserAgent(), Valid: r.UserAgent() != ""},
+	}
+	if err := s.GetDB().CreateAbuseEvent(event); err != nil {
+		fmt.Printf("Warning: failed to record honeypot submission: %v\n", err)
+	}
+}
+
 // checkRSVPDeadline validates if the RSVP deadline has passed
 func checkRSVPDeadline(s Server, w http.ResponseWriter, lang i18n.Language) bool {
 	if time.Now().After(s.GetConfig().RSVPDeadline) {
@@ -100,13 +121,21 @@ func parseRSVPForm(r *http.Request, w http.ResponseWriter, lang i18n.Language) (
 }
 
 // getOrCreateInvitation retrieves an existing invitation by token or phone, or creates a new one
-func getOrCreateInvitation(s Server, formData *rsvpFormData, w http.ResponseWriter) (int64, bool) {
+func getOrCreateInvitation(s Server, formData *rsvpFormData, w http.ResponseWriter, lang i18n.Language) (int64, bool) {
 	if formData.token != "" {
 		invitation, err := s.GetDB().GetInvitationByToken(formData.token)
 		if err != nil {
 			http.Error(w, "Invalid invitation token", http.StatusBadRequest)
 			return 0, false
 		}
+		if invitationLinkStatus(s.GetConfig(), invitation, time.Now()) != "" {
+			errorMsg := "This invitation link is no longer valid"
+			if lang == "ro" {
+				errorMsg = "Acest link de invitație nu mai este valid"
+			}
+			http.Error(w, errorMsg, http.StatusForbidden)
+			return 0, false
+		}
 		return invitation.ID, true
 	}
 
@@ -118,7 +147,7 @@ func getOrCreateInvitation(s Server, formData *rsvpFormData, w http.ResponseWrit
 	}
 
 	// If no existing invitation, create a new one
-	invitation, err = s.GetDB().CreateInvitation(formData.guestName, formData.phone, "")
+	invitation, err = s.GetDB().CreateInvitation(formData.guestName, formData.phone, "", nil)
 	if err != nil {
 		http.Error(w, "Failed to create invitation", http.StatusInternalServerError)
 		return 0, false
@@ -133,6 +162,30 @@ func getOrCreateInvitation(s Server, formData *rsvpFormData, w http.ResponseWrit
 	return invitation.ID, true
 }
 
+// markFormStarted records that the guest started the RSVP form during the visit posted in the form
+func markFormStarted(s Server, r *http.Request) {
+	visitID, err := strconv.ParseInt(r.FormValue("visit"), 10, 64)
+	if err != nil || r.FormValue("token") == "" {
+		return
+	}
+	if err := s.GetDB().MarkVisitFormStarted(visitID, r.FormValue("token")); err != nil {
+		// Log but don't fail - this is just tracking
+		fmt.Printf("Warning: failed to mark visit form as started: %v\n", err)
+	}
+}
+
+// HandleRSVPStarted is called by the RSVP form the first time the guest interacts with it
+func HandleRSVPStarted(s Server) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			http.Redirect(w, r, "/", http.StatusSeeOther)
+			return
+		}
+		markFormStarted(s, r)
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
 // HandleRSVPSubmit processes RSVP form submissions
 func HandleRSVPSubmit(s Server) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
@@ -143,6 +196,13 @@ func HandleRSVPSubmit(s Server) http.HandlerFunc {
 
 		lang := i18n.GetLanguageFromRequest(r)
 
+		// Bots fill the hidden honeypot field; pretend it worked without saving anything
+		if r.FormValue(honeypotField) != "" {
+			recordHoneypot(s, r)
+			http.Redirect(w, r, "/?submitted=true&lang="+string(lang), http.StatusSeeOther)
+			return
+		}
+
 		// Check if the RSVP deadline has passed
 		if !checkRSVPDeadline(s, w, lang) {
 			return
@@ -155,7 +215,7 @@ func HandleRSVPSubmit(s Server) http.HandlerFunc {
 		}
 
 		// Get or create invitation
-		invitationID, ok := getOrCreateInvitation(s, formData, w)
+		invitationID, ok := getOrCreateInvitation(s, formData, w, lang)
 		if !ok {
 			return
 		}
@@ -182,11 +242,56 @@ func HandleRSVPSubmit(s Server) http.HandlerFunc {
 			return
 		}
 
+		markFormStarted(s, r)
+
 		// Redirect to thank you page with language
 		redirectURL := "/?submitted=true&lang=" + string(lang)
+		if formData.attending {
+			redirectURL += "&attending=yes"
+		}
 		if formData.token != "" {
 			redirectURL += "&token=" + formData.token
 		}
 		http.Redirect(w, r, redirectURL, http.StatusSeeOther)
 	}
 }
+
+// HandleRSVPWithdraw withdraws a guest's current response before the deadline
+func HandleRSVPWithdraw(s Server) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			http.Redirect(w, r, "/", http.StatusSeeOther)
+			return
+		}
+
+		lang := i18n.GetLanguageFromRequest(r)
+
+		if !checkRSVPDeadline(s, w, lang) {
+			return
+		}
+
+		token := r.FormValue("token")
+		invitation, err := s.GetDB().GetInvitationByToken(token)
+		if err != nil {
+			http.Error(w, "Invalid invitation token", http.StatusBadRequest)
+			return
+		}
+		if invitationLinkStatus(s.GetConfig(), invitation, time.Now()) != "" {
+			errorMsg := "This invitation link is no longer valid"
+			if lang == "ro" {
+				errorMsg = "Acest link de invitație nu mai este valid"
+			}
+			http.Error(w, errorMsg, http.StatusForbidden)
+			return
+		}
+
+		// Withdrawing again (a double submit or a second tab) leaves nothing to withdraw,
+		// which is what the guest wanted anyway
+		if err := s.GetDB().WithdrawResponse(invitation.ID); err != nil && !errors.Is(err, database.ErrNoResponse) {
+			http.Error(w, "Failed to withdraw response", http.StatusInternalServerError)
+			return
+		}
+
+		http.Redirect(w, r, "/?withdrawn=true&lang="+string(lang)+"&token="+token, http.StatusSeeOther)
+	}
+}
@@ -0,0 +1,54 @@
+package handlers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/AlexTLDR/evite/internal/config"
+	"github.com/AlexTLDR/evite/internal/database"
+	"github.com/AlexTLDR/evite/internal/database/dbtest"
+)
+
+// testServer is a Server backed by the test database
+type testServer struct {
+	db  *database.DB
+	cfg *config.Config
+}
+
+func (s *testServer) GetDB() *database.DB       { return s.db }
+func (s *testServer) GetConfig() *config.Config { return s.cfg }
+
+func TestHandleRSVPWithdrawTwice(t *testing.T) {
+	db := dbtest.Open(t)
+	s := &testServer{db: db, cfg: &config.Config{RSVPDeadline: time.Now().Add(24 * time.Hour)}}
+
+	invitation := dbtest.CreateInvitation(t, db, "Withdraw Twice")
+	if _, err := db.CreateResponse(invitation.ID, true, false, "", "", 0, "standard", "", ""); err != nil {
+		t.Fatalf("Failed to create response: %v", err)
+	}
+
+	// The second request finds nothing left to withdraw, e.g. after a double submit
+	for attempt := 1; attempt <= 2; attempt++ {
+		form := url.Values{"token": {invitation.Token}}
+		req := httptest.NewRequest(http.MethodPost, "/rsvp/withdraw", strings.NewReader(form.Encode()))
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		rec := httptest.NewRecorder()
+
+		HandleRSVPWithdraw(s)(rec, req)
+
+		if rec.Code != http.StatusSeeOther {
+			t.Fatalf("Attempt %d: expected status %d but got %d: %s", attempt, http.StatusSeeOther, rec.Code, rec.Body.String())
+		}
+		if location := rec.Header().Get("Location"); !strings.Contains(location, "withdrawn=true") {
+			t.Errorf("Attempt %d: expected a redirect to the withdrawn page but got %s", attempt, location)
+		}
+	}
+
+	if response, err := db.GetLatestResponseByInvitationID(invitation.ID); err != nil || response != nil {
+		t.Errorf("Expected no current response after withdrawing but got %+v (%v)", response, err)
+	}
+}
@@ -0,0 +1,30 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/AlexTLDR/evite/internal/config"
+	"github.com/AlexTLDR/evite/templates"
+)
+
+// securityPageLimit is the maximum number of abuse events shown on the security page
+const securityPageLimit = 200
+
+// HandleAdminSecurity lists rate-limit lockouts and blocked bot submissions
+func HandleAdminSecurity(s AdminServer) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		_, userName := s.GetCurrentUser(r)
+		lang := adminLanguage(s, r)
+
+		events, err := s.GetDB().GetAbuseEvents(securityPageLimit)
+		if err != nil {
+			http.Error(w, "Failed to load security events", http.StatusInternalServerError)
+			return
+		}
+
+		themes := config.GetThemes()
+		if err := templates.AdminSecurity(string(lang), userName, events, securityPageLimit, themes.Light, themes.Dark).Render(r.Context(), w); err != nil {
+			http.Error(w, "Failed to render page", http.StatusInternalServerError)
+		}
+	}
+}
@@ -0,0 +1,99 @@
+package handlers
+
+import (
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/AlexTLDR/evite/internal/auth"
+	"github.com/AlexTLDR/evite/internal/config"
+	"github.com/AlexTLDR/evite/internal/database"
+	"github.com/AlexTLDR/evite/templates"
+)
+
+// sessionSnapshot is the JSON representation of a session stored in the audit log
+type sessionSnapshot struct {
+	Email     string    `json:"email"`
+	UserAgent string    `json:"user_agent,omitempty"`
+	IP        string    `json:"ip,omitempty"`
+	LastSeen  time.Time `json:"last_seen"`
+}
+
+// HandleAdminSessions lists active admin sessions
+// Owners see every admin's sessions, other admins only their own
+func HandleAdminSessions(s AdminServer) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		email, userName := s.GetCurrentUser(r)
+		lang := adminLanguage(s, r)
+
+		showAll := auth.Can(r.Context(), auth.PermManageAdmins)
+		filterEmail := email
+		if showAll {
+			filterEmail = ""
+		}
+
+		now := time.Now()
+		sessions, err := s.GetDB().GetActiveAdminSessions(filterEmail, now.Add(-s.GetConfig().SessionIdleTimeout), now)
+		if err != nil {
+			http.Error(w, "Failed to load sessions", http.StatusInternalServerError)
+			return
+		}
+
+		var currentID int64
+		if current := s.GetCurrentSession(r); current != nil {
+			currentID = current.ID
+		}
+
+		themes := config.GetThemes()
+		if err := templates.AdminSessions(string(lang), userName, currentID, sessions, showAll, themes.Light, themes.Dark).Render(r.Context(), w); err != nil {
+			http.Error(w, "Failed to render page", http.StatusInternalServerError)
+		}
+	}
+}
+
+// HandleAdminRevokeSession signs a session out remotely
+func HandleAdminRevokeSession(s AdminServer) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			http.Redirect(w, r, "/admin/sessions", http.StatusSeeOther)
+			return
+		}
+		if err := r.ParseForm(); err != nil {
+			http.Error(w, "Invalid form", http.StatusBadRequest)
+			return
+		}
+
+		id, err := parseID(r.FormValue("id"))
+		if err != nil {
+			http.Error(w, "Invalid session ID", http.StatusBadRequest)
+			return
+		}
+
+		session, err := s.GetDB().GetAdminSessionByID(id)
+		if err != nil {
+			http.Error(w, "Failed to load session", http.StatusInternalServerError)
+			return
+		}
+
+		// Only owners may sign out other admins
+		email, _ := s.GetCurrentUser(r)
+		if session == nil || (!strings.EqualFold(session.Email, email) && !auth.Can(r.Context(), auth.PermManageAdmins)) {
+			http.Error(w, "Session not found", http.StatusNotFound)
+			return
+		}
+
+		if err := s.GetDB().DeleteAdminSession(session.ID); err != nil {
+			http.Error(w, "Failed to sign out session", http.StatusInternalServerError)
+			return
+		}
+
+		recordAudit(s, r, database.AuditSessionRevoke, 0, session.Email, sessionSnapshot{
+			Email:     session.Email,
+			UserAgent: session.UserAgent.String,
+			IP:        session.IP.String,
+			LastSeen:  session.LastSeenAt,
+		}, nil)
+
+		http.Redirect(w, r, "/admin/sessions", http.StatusSeeOther)
+	}
+}
@@ -0,0 +1,120 @@
+package server
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/AlexTLDR/evite/internal/database"
+	"github.com/AlexTLDR/evite/internal/ratelimit"
+	"github.com/AlexTLDR/evite/internal/utils"
+)
+
+// requestToken returns the invitation token a public request refers to, if any
+func requestToken(r *http.Request) string {
+	if token := r.URL.Query().Get("token"); token != "" {
+		return token
+	}
+	if token, ok := strings.CutPrefix(r.URL.Path, "/rsvp/"); ok && token != "submit" && token != "started" && token != "withdraw" {
+		return token
+	}
+	if token, ok := strings.CutPrefix(r.URL.Path, "/calendar/"); ok && token != "event.ics" {
+		return strings.TrimSuffix(token, ".ics")
+	}
+	if token, ok := strings.CutPrefix(r.URL.Path, "/og/"); ok && token != "event.png" {
+		return strings.TrimSuffix(token, ".png")
+	}
+	if r.Method == http.MethodPost {
+		return r.FormValue("token")
+	}
+	return ""
+}
+
+// isUnknownInvitation reports whether a request refers to an invitation token or a typed
+// invitation code that does not exist
+func (s *Server) isUnknownInvitation(r *http.Request) bool {
+	if r.URL.Path == "/code" {
+		input := r.FormValue("code")
+		if input == "" {
+			return false
+		}
+		code := utils.NormalizeInviteCode(input)
+		if code == "" {
+			return true
+		}
+		_, err := s.db.GetInvitationByCode(code)
+		return errors.Is(err, sql.ErrNoRows)
+	}
+
+	token := requestToken(r)
+	if token == "" {
+		return false
+	}
+	_, err := s.db.GetInvitationByToken(token)
+	return errors.Is(err, sql.ErrNoRows)
+}
+
+// rateLimit is a middleware that limits public requests per client IP, and requests with
+// unknown invitation tokens or codes per client IP so that they cannot be guessed
+// Valid tokens are not limited here: someone who has seen a guest's link must not be able
+// to lock the guest out of their page; RSVP writes get a generous budget in limitTokenWrites
+func (s *Server) rateLimit(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		now := time.Now()
+		ip := utils.ClientIP(r)
+
+		if !s.checkLimit(w, r, s.ipLimiter, database.AbuseRateLimitIP, ip, now) {
+			return
+		}
+		if s.isUnknownInvitation(r) {
+			if !s.checkLimit(w, r, s.unknownTokenLimiter, database.AbuseRateLimitToken, ip, now) {
+				return
+			}
+		}
+
+		next(w, r)
+	}
+}
+
+// limitTokenWrites is a middleware that limits the RSVP writes made with one invitation token,
+// so a leaked link cannot be used to flood the invitation with submissions
+func (s *Server) limitTokenWrites(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if token := requestToken(r); token != "" {
+			if !s.checkLimit(w, r, s.tokenWriteLimiter, database.AbuseRateLimitWrite, token, time.Now()) {
+				return
+			}
+		}
+		next(w, r)
+	}
+}
+
+// checkLimit records a request against a limiter
+// Returns false and answers with 429 if the key is locked out
+func (s *Server) checkLimit(w http.ResponseWriter, r *http.Request, limiter *ratelimit.Limiter, kind, key string, now time.Time) bool {
+	result := limiter.Allow(key, now)
+	if result.Allowed {
+		return true
+	}
+
+	// Log each lockout once, not every blocked request
+	if result.LockedOut {
+		event := &database.AbuseEvent{
+			Kind:      kind,
+			Key:       sql.NullString{String: key, Valid: true},
+			IP:        sql.NullString{String: utils.ClientIP(r), Valid: true},
+			Path:      sql.NullString{String: r.URL.Path, Valid: true},
+			UserAgent: sql.NullString{String: r.UserAgent(), Valid: r.UserAgent() != ""},
+		}
+		if err := s.db.CreateAbuseEvent(event); err != nil {
+			fmt.Printf("Warning: failed to record lockout: %v\n", err)
+		}
+	}
+
+	w.Header().Set("Retry-After", fmt.Sprintf("%d", int(result.RetryAfter.Seconds())))
+	http.Error(w, "Too many requests, please try again later", http.StatusTooManyRequests)
+	return false
+}
@@ -0,0 +1,57 @@
+package server
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/AlexTLDR/evite/internal/config"
+	"github.com/AlexTLDR/evite/internal/database/dbtest"
+)
+
+func TestRateLimitUnknownCodes(t *testing.T) {
+	db := dbtest.Open(t)
+	s := New(&config.Config{
+		SessionSecret:          strings.Repeat("s", 32),
+		RateLimitPerIP:         100,
+		RateLimitUnknownTokens: 3,
+		RateLimitWindow:        time.Minute,
+		RateLimitLockout:       time.Minute,
+	}, db)
+
+	submitCode := func(code string) *httptest.ResponseRecorder {
+		form := url.Values{"code": {code}}
+		req := httptest.NewRequest(http.MethodPost, "/code", strings.NewReader(form.Encode()))
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		req.RemoteAddr = "192.0.2.10:1234"
+		rec := httptest.NewRecorder()
+		s.router.ServeHTTP(rec, req)
+		return rec
+	}
+
+	// A valid code is never counted as a guess
+	invitation := dbtest.CreateInvitation(t, db, "Code Guesser Target")
+	for i := 0; i < 5; i++ {
+		if rec := submitCode(invitation.Code); rec.Code != http.StatusSeeOther {
+			t.Fatalf("Expected the valid code to redirect but got %d", rec.Code)
+		}
+	}
+
+	// Wrong codes, well-formed or not, use up the unknown-invitation budget
+	for i, code := range []string{"ZZZZZZ", "not a code", "YYYYYY"} {
+		if rec := submitCode(code); rec.Code != http.StatusSeeOther {
+			t.Fatalf("Guess %d: expected a redirect to the invalid code page but got %d", i+1, rec.Code)
+		}
+	}
+
+	rec := submitCode("XXXXXX")
+	if rec.Code != http.StatusTooManyRequests {
+		t.Fatalf("Expected %d after too many wrong codes but got %d", http.StatusTooManyRequests, rec.Code)
+	}
+	if rec.Header().Get("Retry-After") == "" {
+		t.Errorf("Expected a Retry-After header on the lockout")
+	}
+}
@@ -1,11 +1,19 @@
 package server
 
 import (
+	"fmt"
+	"net"
 	"net/http"
+	"strings"
+	"sync"
 
+	"github.com/AlexTLDR/evite/internal/auth"
 	"github.com/AlexTLDR/evite/internal/config"
+	"github.com/AlexTLDR/evite/internal/csrf"
 	"github.com/AlexTLDR/evite/internal/database"
+	"github.com/AlexTLDR/evite/internal/ratelimit"
 	"github.com/AlexTLDR/evite/internal/server/handlers"
+	"github.com/AlexTLDR/evite/internal/utils"
 	"github.com/gorilla/sessions"
 )
 
@@ -14,6 +22,16 @@ type Server struct {
 	db           *database.DB
 	sessionStore *sessions.CookieStore
 	router       *http.ServeMux
+
+	// oidcProvider is discovered lazily on the first OIDC login
+	oidcMu       sync.Mutex
+	oidcProvider *auth.OIDCProvider
+
+	// Rate limiters for public routes
+	ipLimiter           *ratelimit.Limiter
+	unknownTokenLimiter *ratelimit.Limiter
+	// Anyone who has seen a guest's link can use up its budget, so its lockout lasts only one window
+	tokenWriteLimiter *ratelimit.Limiter
 }
 
 // GetDB implements handlers.Server interface
@@ -28,18 +46,31 @@ func (s *Server) GetConfig() *config.Config {
 
 // GetCurrentUser implements handlers.AdminServer interface
 func (s *Server) GetCurrentUser(r *http.Request) (string, string) {
-	session, _ := s.sessionStore.Get(r, "auth-session")
-	email, _ := session.Values["email"].(string)
-	name, _ := session.Values["name"].(string)
-	return email, name
+	session := s.GetCurrentSession(r)
+	if session == nil {
+		return "", ""
+	}
+	return session.Email, session.Name.String
+}
+
+// GetCurrentSession implements handlers.AdminServer interface
+func (s *Server) GetCurrentSession(r *http.Request) *database.AdminSession {
+	session, err := s.loadSession(r)
+	if err != nil {
+		fmt.Printf("Warning: failed to load session: %v\n", err)
+	}
+	return session
 }
 
 func New(cfg *config.Config, db *database.DB) *Server {
 	s := &Server{
-		config:       cfg,
-		db:           db,
-		sessionStore: sessions.NewCookieStore([]byte(cfg.SessionSecret)),
-		router:       http.NewServeMux(),
+		config:              cfg,
+		db:                  db,
+		sessionStore:        sessions.NewCookieStore([]byte(cfg.SessionSecret)),
+		router:              http.NewServeMux(),
+		ipLimiter:           ratelimit.New(cfg.RateLimitPerIP, cfg.RateLimitWindow, cfg.RateLimitLockout),
+		unknownTokenLimiter: ratelimit.New(cfg.RateLimitUnknownTokens, cfg.RateLimitWindow, cfg.RateLimitLockout),
+		tokenWriteLimiter:   ratelimit.New(cfg.RateLimitPerToken, cfg.RateLimitWindow, cfg.RateLimitWindow),
 	}
 
 	s.setupRoutes()
@@ -51,58 +82,149 @@ func (s *Server) setupRoutes() {
 	fs := http.FileServer(http.Dir("./static"))
 	s.router.Handle("/static/", http.StripPrefix("/static/", fs))
 
-	// Public routes
-	s.router.HandleFunc("/", handlers.HandleHome(s))
-	s.router.HandleFunc("/rsvp/", handlers.HandleRSVP(s))
-	s.router.HandleFunc("/rsvp/submit", handlers.HandleRSVPSubmit(s))
+	// Public routes (rate limited)
+	// The home page matches "/" exactly; any other path gets the not-found page, outside
+	// the rate limit, instead of rendering the home page and counting against the visitor
+	s.router.HandleFunc("/{$}", s.rateLimit(handlers.HandleHome(s)))
+	s.router.HandleFunc("/", handlers.HandleNotFound(s))
+	s.router.HandleFunc("/rsvp/", s.rateLimit(handlers.HandleRSVP(s)))
+	s.router.HandleFunc("/rsvp/submit", s.rateLimit(s.limitTokenWrites(handlers.HandleRSVPSubmit(s))))
+	s.router.HandleFunc("/rsvp/withdraw", s.rateLimit(s.limitTokenWrites(handlers.HandleRSVPWithdraw(s))))
+	s.router.HandleFunc("/rsvp/started", s.rateLimit(s.limitTokenWrites(handlers.HandleRSVPStarted(s))))
+	s.router.HandleFunc("/code", s.rateLimit(handlers.HandleInviteCode(s)))
+	s.router.HandleFunc("/calendar/event.ics", s.rateLimit(handlers.HandleCalendarEvent(s)))
+	s.router.HandleFunc("/calendar/", s.rateLimit(handlers.HandleCalendarInvitation(s)))
+	s.router.HandleFunc("/og/", s.rateLimit(handlers.HandleLinkPreviewImage(s)))
 
 	// Auth routes
+	s.router.HandleFunc("/auth/login", s.handleLogin)
+	s.router.HandleFunc("/auth/oidc", s.handleOIDCLogin)
+	s.router.HandleFunc("/auth/oidc/callback", s.handleOIDCCallback)
+	s.router.HandleFunc("/auth/email", s.handleEmailLogin)
+	s.router.HandleFunc("/auth/email/verify", s.handleEmailVerify)
 	s.router.HandleFunc("/auth/google", s.handleGoogleLogin)
 	s.router.HandleFunc("/auth/google/callback", s.handleGoogleCallback)
 	s.router.HandleFunc("/auth/logout", s.handleLogout)
 
-	// Admin routes (protected)
-	s.router.HandleFunc("/admin", s.requireAuth(handlers.HandleAdminDashboard(s)))
-	s.router.HandleFunc("/admin/invitations", s.requireAuth(handlers.HandleAdminInvitations(s)))
-	s.router.HandleFunc("/admin/invitations/new", s.requireAuth(handlers.HandleAdminNewInvitation(s)))
-	s.router.HandleFunc("/admin/invitations/create", s.requireAuth(handlers.HandleAdminCreateInvitation(s)))
-	s.router.HandleFunc("/admin/invitations/edit/", s.requireAuth(handlers.HandleAdminEditInvitation(s)))
-	s.router.HandleFunc("/admin/invitations/update/", s.requireAuth(handlers.HandleAdminUpdateInvitation(s)))
-	s.router.HandleFunc("/admin/invitations/delete", s.requireAuth(handlers.HandleAdminDeleteInvitation(s)))
-	s.router.HandleFunc("/admin/invitations/mark-sent", s.requireAuth(handlers.HandleAdminMarkSent(s)))
-	s.router.HandleFunc("/admin/invitations/download-csv", s.requireAuth(handlers.HandleAdminDownloadCSV(s)))
+	// Admin routes (protected, each requiring a permission granted by the admin's role)
+	s.router.HandleFunc("/admin", s.requireAuth(auth.PermView, handlers.HandleAdminDashboard(s)))
+	s.router.HandleFunc("/admin/language", s.requireAuth(auth.PermAccount, handlers.HandleAdminSetLanguage(s)))
+	s.router.HandleFunc("/admin/invitations", s.requireAuth(auth.PermView, handlers.HandleAdminInvitations(s)))
+	s.router.HandleFunc("/admin/invitations/new", s.requireAuth(auth.PermEdit, handlers.HandleAdminNewInvitation(s)))
+	s.router.HandleFunc("/admin/invitations/create", s.requireAuth(auth.PermEdit, handlers.HandleAdminCreateInvitation(s)))
+	s.router.HandleFunc("/admin/invitations/edit/", s.requireAuth(auth.PermEdit, handlers.HandleAdminEditInvitation(s)))
+	s.router.HandleFunc("/admin/invitations/update/", s.requireAuth(auth.PermEdit, handlers.HandleAdminUpdateInvitation(s)))
+	s.router.HandleFunc("/admin/invitations/delete", s.requireAuth(auth.PermDelete, handlers.HandleAdminDeleteInvitation(s)))
+	s.router.HandleFunc("/admin/invitations/archive", s.requireAuth(auth.PermDelete, handlers.HandleAdminArchive(s)))
+	s.router.HandleFunc("/admin/invitations/restore", s.requireAuth(auth.PermDelete, handlers.HandleAdminRestoreInvitation(s)))
+	s.router.HandleFunc("/admin/invitations/purge", s.requireAuth(auth.PermDelete, handlers.HandleAdminPurgeInvitation(s)))
+	s.router.HandleFunc("/admin/invitations/mark-sent", s.requireAuth(auth.PermEdit, handlers.HandleAdminMarkSent(s)))
+	s.router.HandleFunc("/admin/invitations/bulk/mark-sent", s.requireAuth(auth.PermEdit, handlers.HandleAdminBulkMarkSent(s)))
+	s.router.HandleFunc("/admin/invitations/bulk/send", s.requireAuth(auth.PermEdit, handlers.HandleAdminBulkSend(s)))
+	s.router.HandleFunc("/admin/invitations/bulk/tag", s.requireAuth(auth.PermEdit, handlers.HandleAdminBulkTag(s)))
+	s.router.HandleFunc("/admin/invitations/bulk/archive", s.requireAuth(auth.PermDelete, handlers.HandleAdminBulkArchive(s)))
+	s.router.HandleFunc("/admin/invitations/bulk/export", s.requireAuth(auth.PermReports, handlers.HandleAdminBulkExport(s)))
+	s.router.HandleFunc("/admin/invitations/regenerate-token", s.requireAuth(auth.PermEdit, handlers.HandleAdminRegenerateToken(s)))
+	s.router.HandleFunc("/admin/invitations/revoke", s.requireAuth(auth.PermEdit, handlers.HandleAdminRevokeInvitation(s)))
+	s.router.HandleFunc("/admin/invitations/preview/", s.requireAuth(auth.PermView, handlers.HandleAdminPreviewInvitation(s)))
+	s.router.HandleFunc("/admin/invitations/history/", s.requireAuth(auth.PermView, handlers.HandleAdminResponseHistory(s)))
+	s.router.HandleFunc("/admin/invitations/activity/", s.requireAuth(auth.PermView, handlers.HandleAdminInvitationActivity(s)))
+	s.router.HandleFunc("/admin/invitations/download-csv", s.requireAuth(auth.PermReports, handlers.HandleAdminDownloadCSV(s)))
+	s.router.HandleFunc("/admin/invitations/qr/", s.requireAuth(auth.PermReports, handlers.HandleAdminInvitationQR(s)))
+	s.router.HandleFunc("/admin/invitations/qr.zip", s.requireAuth(auth.PermReports, handlers.HandleAdminDownloadQRZip(s)))
+	s.router.HandleFunc("/admin/reports/catering", s.requireAuth(auth.PermReports, handlers.HandleAdminCateringReport(s)))
+	s.router.HandleFunc("/admin/reports/catering/print", s.requireAuth(auth.PermReports, handlers.HandleAdminCateringPrint(s)))
+	s.router.HandleFunc("/admin/cards", s.requireAuth(auth.PermReports, handlers.HandleAdminCards(s)))
+	s.router.HandleFunc("/admin/cards/download", s.requireAuth(auth.PermReports, handlers.HandleAdminDownloadCards(s)))
+	s.router.HandleFunc("/admin/audit", s.requireAuth(auth.PermManageAdmins, handlers.HandleAdminAudit(s)))
+	s.router.HandleFunc("/admin/audit/download-csv", s.requireAuth(auth.PermManageAdmins, handlers.HandleAdminAuditCSV(s)))
+	s.router.HandleFunc("/admin/admins", s.requireAuth(auth.PermManageAdmins, handlers.HandleAdminAdmins(s)))
+	s.router.HandleFunc("/admin/admins/create", s.requireAuth(auth.PermManageAdmins, handlers.HandleAdminCreateAdmin(s)))
+	s.router.HandleFunc("/admin/admins/role", s.requireAuth(auth.PermManageAdmins, handlers.HandleAdminUpdateAdminRole(s)))
+	s.router.HandleFunc("/admin/admins/delete", s.requireAuth(auth.PermManageAdmins, handlers.HandleAdminDeleteAdmin(s)))
+	s.router.HandleFunc("/admin/security", s.requireAuth(auth.PermManageAdmins, handlers.HandleAdminSecurity(s)))
+	s.router.HandleFunc("/admin/checkin", s.requireAuth(auth.PermCheckIn, handlers.HandleAdminCheckIn(s)))
+	s.router.HandleFunc("/admin/checkin/mark", s.requireAuth(auth.PermCheckIn, handlers.HandleAdminCheckInGuest(s)))
+	s.router.HandleFunc("/admin/sessions", s.requireAuth(auth.PermAccount, handlers.HandleAdminSessions(s)))
+	s.router.HandleFunc("/admin/sessions/revoke", s.requireAuth(auth.PermAccount, handlers.HandleAdminRevokeSession(s)))
 }
 
 func (s *Server) Start(addr string) error {
-	return http.ListenAndServe(addr, s.router)
+	// Every POST (admin actions and RSVP submissions) must carry the CSRF token
+	secureCookie := strings.HasPrefix(s.config.BaseURL, "https://")
+	return http.ListenAndServe(addr, s.trustProxies(csrf.Middleware(s.config.SessionSecret, secureCookie, s.router)))
+}
+
+// trustProxies is a middleware that replaces the remote address of requests coming through
+// a trusted reverse proxy with the client address the proxy forwarded
+// Everything that needs the client IP (rate limits, audit log, sessions) reads it from RemoteAddr
+func (s *Server) trustProxies(next http.Handler) http.Handler {
+	if len(s.config.TrustedProxies) == 0 {
+		return next
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ip := utils.ForwardedClientIP(r, s.config.TrustedProxies)
+		// Handlers must not modify the incoming request, so rewrite a copy
+		r = r.Clone(r.Context())
+		r.RemoteAddr = net.JoinHostPort(ip, "0")
+		next.ServeHTTP(w, r)
+	})
 }
 
 // requireAuth is a middleware that checks if user is authenticated
-func (s *Server) requireAuth(next http.HandlerFunc) http.HandlerFunc {
+// and that their admin role grants the given permission
+func (s *Server) requireAuth(permission auth.Permission, next http.HandlerFunc) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
-		session, _ := s.sessionStore.Get(r, "auth-session")
-
-		email, ok := session.Values["email"].(string)
-		if !ok || email == "" {
-			http.Redirect(w, r, "/auth/google", http.StatusSeeOther)
+		session, err := s.loadSession(r)
+		if err != nil {
+			http.Error(w, "Failed to load session", http.StatusInternalServerError)
 			return
 		}
+		if session == nil {
+			http.Redirect(w, r, "/auth/login", http.StatusSeeOther)
+			return
+		}
+		r = withSession(r, session)
 
-		// Check if email is in whitelist
-		if !s.isAdminEmail(email) {
+		// Look up the admin on every request so role changes and removals apply immediately
+		role, err := s.adminRole(session.Email)
+		if err != nil {
+			http.Error(w, "Failed to check admin access", http.StatusInternalServerError)
+			return
+		}
+		if role == "" {
 			http.Error(w, "Unauthorized", http.StatusUnauthorized)
 			return
 		}
 
-		next(w, r)
+		if !role.Can(permission) {
+			// Check-in staff have no dashboard; send them to the guest list they work from
+			if r.URL.Path == "/admin" && role.Can(auth.PermCheckIn) {
+				http.Redirect(w, r, "/admin/checkin", http.StatusSeeOther)
+				return
+			}
+			http.Error(w, "Forbidden: Your role does not allow this action", http.StatusForbidden)
+			return
+		}
+
+		next(w, r.WithContext(auth.WithRole(r.Context(), role)))
 	}
 }
 
-func (s *Server) isAdminEmail(email string) bool {
-	for _, adminEmail := range s.config.AdminEmails {
-		if email == adminEmail {
-			return true
-		}
+// adminRole returns the role of the admin with the given email
+// Returns an empty role if the email is not an admin
+func (s *Server) adminRole(email string) (auth.Role, error) {
+	admin, err := s.db.GetAdminByEmail(email)
+	if err != nil {
+		return "", err
+	}
+	if admin == nil {
+		return "", nil
+	}
+
+	role, ok := auth.ParseRole(admin.Role)
+	if !ok {
+		return "", fmt.Errorf("unknown role %q for admin %s", admin.Role, email)
 	}
-	return false
+	return role, nil
 }
@@ -0,0 +1,135 @@
+package server
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/AlexTLDR/evite/internal/database"
+	"github.com/AlexTLDR/evite/internal/utils"
+)
+
+const (
+	// sessionCookieName is the cookie holding the admin session token
+	sessionCookieName = "auth-session"
+	// sessionTouchInterval limits how often last-seen times are written
+	sessionTouchInterval = time.Minute
+)
+
+type sessionContextKey struct{}
+
+// hashSessionToken returns the hash under which a session token is stored
+func hashSessionToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// createSession starts a new admin session and sets its cookie
+// A fresh token is issued on every login so an old cookie can't be fixed in place
+func (s *Server) createSession(w http.ResponseWriter, r *http.Request, email, name string) error {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return fmt.Errorf("failed to generate session token: %w", err)
+	}
+	token := base64.RawURLEncoding.EncodeToString(b)
+
+	now := time.Now()
+	session := &database.AdminSession{
+		TokenHash:  hashSessionToken(token),
+		Email:      email,
+		Name:       sql.NullString{String: name, Valid: name != ""},
+		UserAgent:  sql.NullString{String: r.UserAgent(), Valid: r.UserAgent() != ""},
+		IP:         sql.NullString{String: utils.ClientIP(r), Valid: true},
+		CreatedAt:  now,
+		LastSeenAt: now,
+		ExpiresAt:  now.Add(s.config.SessionMaxAge),
+	}
+	if err := s.db.CreateAdminSession(session); err != nil {
+		return err
+	}
+
+	// Replace the session this browser had before, if any
+	s.destroySession(w, r)
+
+	http.SetCookie(w, &http.Cookie{
+		Name:     sessionCookieName,
+		Value:    token,
+		Path:     "/",
+		Expires:  session.ExpiresAt,
+		HttpOnly: true,
+		Secure:   strings.HasPrefix(s.config.BaseURL, "https://"),
+		SameSite: http.SameSiteLaxMode,
+	})
+
+	if err := s.db.DeleteExpiredAdminSessions(now.Add(-s.config.SessionIdleTimeout), now); err != nil {
+		fmt.Printf("Warning: failed to delete expired sessions: %v\n", err)
+	}
+
+	return nil
+}
+
+// loadSession returns the admin session of the request
+// Returns nil if there is no session or it has passed its idle or absolute timeout
+func (s *Server) loadSession(r *http.Request) (*database.AdminSession, error) {
+	if session, ok := r.Context().Value(sessionContextKey{}).(*database.AdminSession); ok {
+		return session, nil
+	}
+
+	cookie, err := r.Cookie(sessionCookieName)
+	if err != nil || cookie.Value == "" {
+		return nil, nil
+	}
+
+	session, err := s.db.GetAdminSession(hashSessionToken(cookie.Value))
+	if err != nil || session == nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	if !now.Before(session.ExpiresAt) || now.Sub(session.LastSeenAt) >= s.config.SessionIdleTimeout {
+		if err := s.db.DeleteAdminSession(session.ID); err != nil {
+			fmt.Printf("Warning: failed to delete expired session: %v\n", err)
+		}
+		return nil, nil
+	}
+
+	if now.Sub(session.LastSeenAt) >= sessionTouchInterval {
+		if err := s.db.TouchAdminSession(session.ID, utils.ClientIP(r), now); err != nil {
+			fmt.Printf("Warning: failed to update session: %v\n", err)
+		}
+	}
+
+	return session, nil
+}
+
+// withSession returns a copy of r carrying the loaded session
+func withSession(r *http.Request, session *database.AdminSession) *http.Request {
+	return r.WithContext(context.WithValue(r.Context(), sessionContextKey{}, session))
+}
+
+// destroySession deletes the session of the request and clears its cookie
+func (s *Server) destroySession(w http.ResponseWriter, r *http.Request) {
+	if cookie, err := r.Cookie(sessionCookieName); err == nil && cookie.Value != "" {
+		session, err := s.db.GetAdminSession(hashSessionToken(cookie.Value))
+		if err == nil && session != nil {
+			if err := s.db.DeleteAdminSession(session.ID); err != nil {
+				fmt.Printf("Warning: failed to delete session: %v\n", err)
+			}
+		}
+	}
+
+	http.SetCookie(w, &http.Cookie{
+		Name:     sessionCookieName,
+		Value:    "",
+		Path:     "/",
+		MaxAge:   -1,
+		HttpOnly: true,
+	})
+}
@@ -0,0 +1,45 @@
+package utils
+
+import (
+	"crypto/rand"
+	"fmt"
+	"math/big"
+	"strings"
+)
+
+// InviteCodeLength is the number of characters in an invitation code
+const InviteCodeLength = 6
+
+// inviteCodeAlphabet leaves out characters that are easily confused when typed from a card (0/O, 1/I/L)
+const inviteCodeAlphabet = "ABCDEFGHJKMNPQRSTUVWXYZ23456789"
+
+// GenerateInviteCode generates a random short code guests can type in instead of following a link
+func GenerateInviteCode() (string, error) {
+	max := big.NewInt(int64(len(inviteCodeAlphabet)))
+	code := make([]byte, InviteCodeLength)
+	for i := range code {
+		n, err := rand.Int(rand.Reader, max)
+		if err != nil {
+			return "", fmt.Errorf("failed to generate invite code: %w", err)
+		}
+		code[i] = inviteCodeAlphabet[n.Int64()]
+	}
+	return string(code), nil
+}
+
+// NormalizeInviteCode converts a typed code to its stored form
+// Case, spaces and dashes are ignored; returns "" if the input cannot be a valid code
+func NormalizeInviteCode(input string) string {
+	code := strings.ToUpper(input)
+	code = strings.NewReplacer(" ", "", "-", "").Replace(code)
+
+	if len(code) != InviteCodeLength {
+		return ""
+	}
+	for _, c := range code {
+		if !strings.ContainsRune(inviteCodeAlphabet, c) {
+			return ""
+		}
+	}
+	return code
+}
@@ -0,0 +1,74 @@
+package utils
+
+import (
+	"testing"
+)
+
+func TestGenerateInviteCode(t *testing.T) {
+	seen := make(map[string]bool)
+	for i := 0; i < 100; i++ {
+		code, err := GenerateInviteCode()
+		if err != nil {
+			t.Fatalf("GenerateInviteCode() error = %v", err)
+		}
+		if NormalizeInviteCode(code) != code {
+			t.Errorf("GenerateInviteCode() = %q, which does not normalize to itself", code)
+		}
+		seen[code] = true
+	}
+	if len(seen) < 95 {
+		t.Errorf("GenerateInviteCode() produced only %d distinct codes out of 100", len(seen))
+	}
+}
+
+func TestNormalizeInviteCode(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{
+			name:     "Already normalized",
+			input:    "ABC234",
+			expected: "ABC234",
+		},
+		{
+			name:     "Lowercase",
+			input:    "abc234",
+			expected: "ABC234",
+		},
+		{
+			name:     "With spaces and dashes",
+			input:    " abc-234 ",
+			expected: "ABC234",
+		},
+		{
+			name:     "Too short",
+			input:    "ABC23",
+			expected: "",
+		},
+		{
+			name:     "Too long",
+			input:    "ABC2345",
+			expected: "",
+		},
+		{
+			name:     "Ambiguous characters",
+			input:    "ABC0I1",
+			expected: "",
+		},
+		{
+			name:     "Empty",
+			input:    "",
+			expected: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := NormalizeInviteCode(tt.input); got != tt.expected {
+				t.Errorf("NormalizeInviteCode(%q) = %q, want %q", tt.input, got, tt.expected)
+			}
+		})
+	}
+}
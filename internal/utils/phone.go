@@ -1,6 +1,7 @@
 package utils
 
 import (
+	"net/url"
 	"strings"
 
 	"github.com/nyaruka/phonenumbers"
@@ -26,3 +27,23 @@ func NormalizePhoneNumber(phone string) (string, error) {
 	// Format to E.164 (e.g., +40721234567)
 	return phonenumbers.Format(num, phonenumbers.E164), nil
 }
+
+// Channels an invitation message can be sent through from the admin's device
+const (
+	ChannelWhatsApp = "whatsapp"
+	ChannelSMS      = "sms"
+)
+
+// MessageLink returns a link that opens the given channel with a message prefilled for an E.164 phone number
+// It returns false for unknown channels
+func MessageLink(channel, phone, message string) (string, bool) {
+	// Spaces are encoded as %20: messaging apps do not all decode "+" in these links
+	text := strings.ReplaceAll(url.QueryEscape(message), "+", "%20")
+	switch channel {
+	case ChannelWhatsApp:
+		return "https://wa.me/" + strings.TrimPrefix(phone, "+") + "?text=" + text, true
+	case ChannelSMS:
+		return "sms:" + phone + "?body=" + text, true
+	}
+	return "", false
+}
@@ -154,3 +154,58 @@ func TestNormalizePhoneNumber(t *testing.T) {
 		})
 	}
 }
+
+func TestMessageLink(t *testing.T) {
+	tests := []struct {
+		name     string
+		channel  string
+		phone    string
+		message  string
+		expected string
+		ok       bool
+	}{
+		{
+			name:     "WhatsApp",
+			channel:  ChannelWhatsApp,
+			phone:    "+40721234567",
+			message:  "Salut Ana!",
+			expected: "https://wa.me/40721234567?text=Salut%20Ana%21",
+			ok:       true,
+		},
+		{
+			name:     "WhatsApp escapes links and line breaks",
+			channel:  ChannelWhatsApp,
+			phone:    "+40721234567",
+			message:  "Hi\nhttps://example.com/rsvp/abc?x=1&y=2",
+			expected: "https://wa.me/40721234567?text=Hi%0Ahttps%3A%2F%2Fexample.com%2Frsvp%2Fabc%3Fx%3D1%26y%3D2",
+			ok:       true,
+		},
+		{
+			name:     "SMS keeps the plus sign",
+			channel:  ChannelSMS,
+			phone:    "+40721234567",
+			message:  "1 + 1",
+			expected: "sms:+40721234567?body=1%20%2B%201",
+			ok:       true,
+		},
+		{
+			name:    "Unknown channel",
+			channel: "fax",
+			phone:   "+40721234567",
+			message: "Hi",
+			ok:      false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, ok := MessageLink(tt.channel, tt.phone, tt.message)
+			if ok != tt.ok {
+				t.Fatalf("Expected ok=%v but got %v", tt.ok, ok)
+			}
+			if result != tt.expected {
+				t.Errorf("Expected %q but got %q", tt.expected, result)
+			}
+		})
+	}
+}
@@ -0,0 +1,222 @@
+package utils
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+)
+
+// ClientIP returns the IP address of the client that made the request
+// X-Forwarded-For is ignored because clients can set it to anything; requests that come
+// through a trusted reverse proxy get their address from ForwardedClientIP instead
+func ClientIP(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
+
+// ParseTrustedProxies parses a comma-separated list of IP addresses and CIDR ranges
+func ParseTrustedProxies(value string) ([]*net.IPNet, error) {
+	var proxies []*net.IPNet
+	for _, entry := range strings.Split(value, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		if !strings.Contains(entry, "/") {
+			ip := net.ParseIP(entry)
+			if ip == nil {
+				return nil, fmt.Errorf("invalid proxy address %q", entry)
+			}
+			bits := 8 * net.IPv4len
+			if ip.To4() == nil {
+				bits = 8 * net.IPv6len
+			}
+			entry = fmt.Sprintf("%s/%d", entry, bits)
+		}
+		_, network, err := net.ParseCIDR(entry)
+		if err != nil {
+			return nil, fmt.Errorf("invalid proxy range %q: %w", entry, err)
+		}
+		proxies = append(proxies, network)
+	}
+	return proxies, nil
+}
+
+// isTrustedProxy reports whether ip belongs to one of the trusted proxy ranges
+func isTrustedProxy(ip string, trusted []*net.IPNet) bool {
+	parsed := net.ParseIP(ip)
+	if parsed == nil {
+		return false
+	}
+	for _, network := range trusted {
+		if network.Contains(parsed) {
+			return true
+		}
+	}
+	return false
+}
+
+// ForwardedClientIP returns the IP address of the client when the request came through one of the
+// trusted proxies: the rightmost X-Forwarded-For entry that is not a trusted proxy itself
+// Entries further left were sent by the client and are never used; requests that did not come
+// from a trusted proxy get their remote address
+func ForwardedClientIP(r *http.Request, trusted []*net.IPNet) string {
+	ip := ClientIP(r)
+	if !isTrustedProxy(ip, trusted) {
+		return ip
+	}
+
+	hops := strings.Split(strings.Join(r.Header.Values("X-Forwarded-For"), ","), ",")
+	for i := len(hops) - 1; i >= 0; i-- {
+		hop := strings.TrimSpace(hops[i])
+		if net.ParseIP(hop) == nil {
+			// Garbage in the header means the hops left of it cannot be trusted either
+			return ip
+		}
+		ip = hop
+		if !isTrustedProxy(hop, trusted) {
+			break
+		}
+	}
+	return ip
+}
+
+// DeviceName returns a short description of the browser and OS in a User-Agent
+// such as "Chrome on Android"; unknown parts are left out
+func DeviceName(userAgent string) string {
+	browsers := []struct{ token, name string }{
+		// Order matters: Edge and Opera also contain "Chrome", Chrome contains "Safari"
+		{"Edg/", "Edge"},
+		{"OPR/", "Opera"},
+		{"SamsungBrowser/", "Samsung Internet"},
+		{"Firefox/", "Firefox"},
+		{"FxiOS/", "Firefox"},
+		{"CriOS/", "Chrome"},
+		{"Chrome/", "Chrome"},
+		{"Safari/", "Safari"},
+	}
+	systems := []struct{ token, name string }{
+		{"iPhone", "iPhone"},
+		{"iPad", "iPad"},
+		{"Android", "Android"},
+		{"Windows", "Windows"},
+		{"Mac OS X", "macOS"},
+		{"CrOS", "ChromeOS"},
+		{"Linux", "Linux"},
+	}
+
+	browser := ""
+	for _, b := range browsers {
+		if strings.Contains(userAgent, b.token) {
+			browser = b.name
+			break
+		}
+	}
+	system := ""
+	for _, s := range systems {
+		if strings.Contains(userAgent, s.token) {
+			system = s.name
+			break
+		}
+	}
+
+	switch {
+	case browser != "" && system != "":
+		return browser + " on " + system
+	case browser != "":
+		return browser
+	case system != "":
+		return system
+	default:
+		return "Unknown device"
+	}
+}
+
+// Device classes returned by DeviceClass
+const (
+	DeviceMobile  = "mobile"
+	DeviceTablet  = "tablet"
+	DeviceDesktop = "desktop"
+	DeviceUnknown = "unknown"
+)
+
+// DeviceClass returns whether a User-Agent belongs to a mobile phone, a tablet or a desktop browser
+func DeviceClass(userAgent string) string {
+	switch {
+	case userAgent == "":
+		return DeviceUnknown
+	case strings.Contains(userAgent, "iPad") || strings.Contains(userAgent, "Tablet"),
+		strings.Contains(userAgent, "Android") && !strings.Contains(userAgent, "Mobile"):
+		return DeviceTablet
+	case strings.Contains(userAgent, "Mobile") || strings.Contains(userAgent, "iPhone") || strings.Contains(userAgent, "Android"):
+		return DeviceMobile
+	default:
+		return DeviceDesktop
+	}
+}
+
+// linkPreviewBots are User-Agent fragments of the crawlers messaging apps and social networks
+// use to build link previews
+var linkPreviewBots = []string{
+	"WhatsApp",
+	"facebookexternalhit",
+	"Facebot",
+	"Twitterbot",
+	"TelegramBot",
+	"Slackbot",
+	"Discordbot",
+	"LinkedInBot",
+	"SkypeUriPreview",
+	"Pinterestbot",
+	"Applebot",
+	"redditbot",
+	"Embedly",
+	"Iframely",
+}
+
+// linkPreviewBotPrefixes are User-Agent prefixes of crawlers whose app names also
+// appear at the end of their in-app browsers' User-Agents, which must not match
+var linkPreviewBotPrefixes = []string{
+	"Viber/",
+	"Pinterest/0.",
+}
+
+// IsLinkPreviewBot reports whether a User-Agent belongs to a link preview crawler
+func IsLinkPreviewBot(userAgent string) bool {
+	for _, bot := range linkPreviewBots {
+		if strings.Contains(userAgent, bot) {
+			return true
+		}
+	}
+	for _, prefix := range linkPreviewBotPrefixes {
+		if strings.HasPrefix(userAgent, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// IsPrefetch reports whether a request was made ahead of time by a browser or proxy
+// (HEAD requests and prefetch/prerender hints) rather than by someone opening the page
+func IsPrefetch(r *http.Request) bool {
+	if r.Method == http.MethodHead {
+		return true
+	}
+	for _, header := range []string{"Purpose", "Sec-Purpose", "X-Purpose", "X-Moz"} {
+		value := strings.ToLower(r.Header.Get(header))
+		if strings.Contains(value, "prefetch") || strings.Contains(value, "prerender") || strings.Contains(value, "preview") {
+			return true
+		}
+	}
+	return false
+}
+
+// IsAutomatedVisit reports whether a request comes from a link preview crawler or a prefetcher
+// Such requests must not count as a guest opening their invitation
+func IsAutomatedVisit(r *http.Request) bool {
+	return IsLinkPreviewBot(r.UserAgent()) || IsPrefetch(r)
+}
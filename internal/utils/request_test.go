@@ -0,0 +1,369 @@
+package utils
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestClientIP(t *testing.T) {
+	tests := []struct {
+		name         string
+		remoteAddr   string
+		forwardedFor string
+		expected     string
+	}{
+		{
+			name:       "Remote address with port",
+			remoteAddr: "192.0.2.1:54321",
+			expected:   "192.0.2.1",
+		},
+		{
+			name:       "Remote address without port",
+			remoteAddr: "192.0.2.1",
+			expected:   "192.0.2.1",
+		},
+		{
+			name:         "Forged forwarded header does not change the limiter key",
+			remoteAddr:   "192.0.2.1:54321",
+			forwardedFor: "203.0.113.7",
+			expected:     "192.0.2.1",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest("GET", "/", nil)
+			r.RemoteAddr = tt.remoteAddr
+			if tt.forwardedFor != "" {
+				r.Header.Set("X-Forwarded-For", tt.forwardedFor)
+			}
+
+			if result := ClientIP(r); result != tt.expected {
+				t.Errorf("Expected %q but got %q", tt.expected, result)
+			}
+		})
+	}
+}
+
+func TestForwardedClientIP(t *testing.T) {
+	trusted, err := ParseTrustedProxies("10.0.0.0/8, 2001:db8::1")
+	if err != nil {
+		t.Fatalf("Failed to parse trusted proxies: %v", err)
+	}
+
+	tests := []struct {
+		name         string
+		remoteAddr   string
+		forwardedFor []string
+		expected     string
+	}{
+		{
+			name:       "Direct request without header",
+			remoteAddr: "192.0.2.1:54321",
+			expected:   "192.0.2.1",
+		},
+		{
+			name:         "Untrusted sender cannot set its IP",
+			remoteAddr:   "192.0.2.1:54321",
+			forwardedFor: []string{"203.0.113.7"},
+			expected:     "192.0.2.1",
+		},
+		{
+			name:         "Trusted proxy forwards the client",
+			remoteAddr:   "10.0.0.1:80",
+			forwardedFor: []string{"203.0.113.7"},
+			expected:     "203.0.113.7",
+		},
+		{
+			name:         "Forged entries left of the proxy hop are ignored",
+			remoteAddr:   "10.0.0.1:80",
+			forwardedFor: []string{"198.51.100.9, 203.0.113.7"},
+			expected:     "203.0.113.7",
+		},
+		{
+			name:         "Chained trusted proxies are skipped",
+			remoteAddr:   "10.0.0.1:80",
+			forwardedFor: []string{"198.51.100.9, 203.0.113.7", "10.0.0.2"},
+			expected:     "203.0.113.7",
+		},
+		{
+			name:         "Trusted IPv6 proxy",
+			remoteAddr:   "[2001:db8::1]:443",
+			forwardedFor: []string{"203.0.113.7"},
+			expected:     "203.0.113.7",
+		},
+		{
+			name:         "Malformed hop falls back to the proxy",
+			remoteAddr:   "10.0.0.1:80",
+			forwardedFor: []string{"203.0.113.7, not-an-ip"},
+			expected:     "10.0.0.1",
+		},
+		{
+			name:       "Trusted proxy without header",
+			remoteAddr: "10.0.0.1:80",
+			expected:   "10.0.0.1",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest("GET", "/", nil)
+			r.RemoteAddr = tt.remoteAddr
+			for _, value := range tt.forwardedFor {
+				r.Header.Add("X-Forwarded-For", value)
+			}
+
+			if result := ForwardedClientIP(r, trusted); result != tt.expected {
+				t.Errorf("Expected %q but got %q", tt.expected, result)
+			}
+		})
+	}
+}
+
+func TestParseTrustedProxies(t *testing.T) {
+	tests := []struct {
+		name     string
+		value    string
+		expected int
+		wantErr  bool
+	}{
+		{name: "Empty", value: "", expected: 0},
+		{name: "Addresses and ranges", value: "10.0.0.1, 172.16.0.0/12,::1", expected: 3},
+		{name: "Invalid address", value: "10.0.0.1,proxy", wantErr: true},
+		{name: "Invalid range", value: "10.0.0.0/33", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			proxies, err := ParseTrustedProxies(tt.value)
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("Expected an error but got none")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if len(proxies) != tt.expected {
+				t.Errorf("Expected %d proxies but got %d", tt.expected, len(proxies))
+			}
+		})
+	}
+}
+
+func TestDeviceName(t *testing.T) {
+	tests := []struct {
+		name      string
+		userAgent string
+		expected  string
+	}{
+		{
+			name:      "Chrome on Android",
+			userAgent: "Mozilla/5.0 (Linux; Android 14; Pixel 8) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/126.0.0.0 Mobile Safari/537.36",
+			expected:  "Chrome on Android",
+		},
+		{
+			name:      "Safari on iPhone",
+			userAgent: "Mozilla/5.0 (iPhone; CPU iPhone OS 17_5 like Mac OS X) AppleWebKit/605.1.15 (KHTML, like Gecko) Version/17.5 Mobile/15E148 Safari/604.1",
+			expected:  "Safari on iPhone",
+		},
+		{
+			name:      "Edge on Windows",
+			userAgent: "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/126.0.0.0 Safari/537.36 Edg/126.0.0.0",
+			expected:  "Edge on Windows",
+		},
+		{
+			name:      "Firefox on macOS",
+			userAgent: "Mozilla/5.0 (Macintosh; Intel Mac OS X 14.5; rv:127.0) Gecko/20100101 Firefox/127.0",
+			expected:  "Firefox on macOS",
+		},
+		{
+			name:      "Unknown",
+			userAgent: "curl/8.5.0",
+			expected:  "Unknown device",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if result := DeviceName(tt.userAgent); result != tt.expected {
+				t.Errorf("Expected %q but got %q", tt.expected, result)
+			}
+		})
+	}
+}
+
+func TestDeviceClass(t *testing.T) {
+	tests := []struct {
+		name      string
+		userAgent string
+		expected  string
+	}{
+		{
+			name:      "iPhone",
+			userAgent: "Mozilla/5.0 (iPhone; CPU iPhone OS 17_5 like Mac OS X) AppleWebKit/605.1.15 (KHTML, like Gecko) Version/17.5 Mobile/15E148 Safari/604.1",
+			expected:  DeviceMobile,
+		},
+		{
+			name:      "Android phone",
+			userAgent: "Mozilla/5.0 (Linux; Android 14; Pixel 8) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/126.0.0.0 Mobile Safari/537.36",
+			expected:  DeviceMobile,
+		},
+		{
+			name:      "Android tablet",
+			userAgent: "Mozilla/5.0 (Linux; Android 13; SM-X700) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/126.0.0.0 Safari/537.36",
+			expected:  DeviceTablet,
+		},
+		{
+			name:      "iPad",
+			userAgent: "Mozilla/5.0 (iPad; CPU OS 17_5 like Mac OS X) AppleWebKit/605.1.15 (KHTML, like Gecko) Version/17.5 Mobile/15E148 Safari/604.1",
+			expected:  DeviceTablet,
+		},
+		{
+			name:      "Desktop",
+			userAgent: "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/126.0.0.0 Safari/537.36",
+			expected:  DeviceDesktop,
+		},
+		{
+			name:      "Empty",
+			userAgent: "",
+			expected:  DeviceUnknown,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if result := DeviceClass(tt.userAgent); result != tt.expected {
+				t.Errorf("Expected %q but got %q", tt.expected, result)
+			}
+		})
+	}
+}
+
+func TestIsLinkPreviewBot(t *testing.T) {
+	tests := []struct {
+		name      string
+		userAgent string
+		expected  bool
+	}{
+		{
+			name:      "WhatsApp",
+			userAgent: "WhatsApp/2.23.20.0 A",
+			expected:  true,
+		},
+		{
+			name:      "Facebook",
+			userAgent: "facebookexternalhit/1.1 (+http://www.facebook.com/externalhit_uatext.php)",
+			expected:  true,
+		},
+		{
+			name:      "Telegram",
+			userAgent: "TelegramBot (like TwitterBot)",
+			expected:  true,
+		},
+		{
+			name:      "Viber link preview",
+			userAgent: "Viber/20.3.0.1 (Android 13; SM-A546B)",
+			expected:  true,
+		},
+		{
+			name:      "Viber in-app browser",
+			userAgent: "Mozilla/5.0 (Linux; Android 13; SM-A546B Build/TP1A.220624.014; wv) AppleWebKit/537.36 (KHTML, like Gecko) Version/4.0 Chrome/119.0.6045.163 Mobile Safari/537.36 Viber/20.3.0.1",
+			expected:  false,
+		},
+		{
+			name:      "Pinterest crawler",
+			userAgent: "Mozilla/5.0 (compatible; Pinterestbot/1.0; +http://www.pinterest.com/bot.html)",
+			expected:  true,
+		},
+		{
+			name:      "Pinterest in-app browser",
+			userAgent: "Mozilla/5.0 (iPhone; CPU iPhone OS 17_5 like Mac OS X) AppleWebKit/605.1.15 (KHTML, like Gecko) Mobile/15E148 [Pinterest/iOS]",
+			expected:  false,
+		},
+		{
+			name:      "Browser",
+			userAgent: "Mozilla/5.0 (iPhone; CPU iPhone OS 17_5 like Mac OS X) AppleWebKit/605.1.15 (KHTML, like Gecko) Version/17.5 Mobile/15E148 Safari/604.1",
+			expected:  false,
+		},
+		{
+			name:      "Empty",
+			userAgent: "",
+			expected:  false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if result := IsLinkPreviewBot(tt.userAgent); result != tt.expected {
+				t.Errorf("Expected %v but got %v", tt.expected, result)
+			}
+		})
+	}
+}
+
+func TestIsAutomatedVisit(t *testing.T) {
+	browser := "Mozilla/5.0 (Linux; Android 14) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/126.0.0.0 Mobile Safari/537.36"
+	tests := []struct {
+		name      string
+		method    string
+		userAgent string
+		headers   map[string]string
+		expected  bool
+	}{
+		{
+			name:      "Browser visit",
+			method:    http.MethodGet,
+			userAgent: browser,
+			expected:  false,
+		},
+		{
+			name:      "Preview crawler",
+			method:    http.MethodGet,
+			userAgent: "WhatsApp/2.23.20.0 A",
+			expected:  true,
+		},
+		{
+			name:      "HEAD request",
+			method:    http.MethodHead,
+			userAgent: browser,
+			expected:  true,
+		},
+		{
+			name:      "Chrome prefetch",
+			method:    http.MethodGet,
+			userAgent: browser,
+			headers:   map[string]string{"Sec-Purpose": "prefetch;prerender"},
+			expected:  true,
+		},
+		{
+			name:      "Legacy prefetch",
+			method:    http.MethodGet,
+			userAgent: browser,
+			headers:   map[string]string{"Purpose": "prefetch"},
+			expected:  true,
+		},
+		{
+			name:      "Safari preview",
+			method:    http.MethodGet,
+			userAgent: browser,
+			headers:   map[string]string{"X-Purpose": "preview"},
+			expected:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest(tt.method, "/?token=abc", nil)
+			r.Header.Set("User-Agent", tt.userAgent)
+			for key, value := range tt.headers {
+				r.Header.Set(key, value)
+			}
+			if result := IsAutomatedVisit(r); result != tt.expected {
+				t.Errorf("Expected %v but got %v", tt.expected, result)
+			}
+		})
+	}
+}
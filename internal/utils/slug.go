@@ -0,0 +1,30 @@
+package utils
+
+import (
+	"strings"
+)
+
+// romanianLetters maps Romanian diacritics (both cedilla and comma forms) to plain ASCII letters
+var romanianLetters = strings.NewReplacer(
+	"ă", "a", "â", "a", "î", "i", "ș", "s", "ş", "s", "ț", "t", "ţ", "t",
+	"Ă", "a", "Â", "a", "Î", "i", "Ș", "s", "Ş", "s", "Ț", "t", "Ţ", "t",
+)
+
+// Slugify converts a name to a lowercase ASCII string safe to use in file names
+// e.g. "Ion Popescu-Țăran" becomes "ion-popescu-taran"
+func Slugify(name string) string {
+	name = strings.ToLower(romanianLetters.Replace(name))
+
+	var b strings.Builder
+	dash := false
+	for _, c := range name {
+		if (c >= 'a' && c <= 'z') || (c >= '0' && c <= '9') {
+			b.WriteRune(c)
+			dash = false
+		} else if !dash && b.Len() > 0 {
+			b.WriteByte('-')
+			dash = true
+		}
+	}
+	return strings.TrimSuffix(b.String(), "-")
+}
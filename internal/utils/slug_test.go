@@ -0,0 +1,47 @@
+package utils
+
+import (
+	"testing"
+)
+
+func TestSlugify(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{
+			name:     "Simple name",
+			input:    "Ion Popescu",
+			expected: "ion-popescu",
+		},
+		{
+			name:     "Romanian diacritics",
+			input:    "Ștefan Țăranu",
+			expected: "stefan-taranu",
+		},
+		{
+			name:     "Cedilla diacritics",
+			input:    "Şerban Ţurcanu",
+			expected: "serban-turcanu",
+		},
+		{
+			name:     "Punctuation and extra spaces",
+			input:    "  Maria & Andrei (familia)  ",
+			expected: "maria-andrei-familia",
+		},
+		{
+			name:     "Only symbols",
+			input:    "???",
+			expected: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Slugify(tt.input); got != tt.expected {
+				t.Errorf("Slugify(%q) = %q, want %q", tt.input, got, tt.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,30 @@
+package utils
+
+import (
+	"strings"
+	"unicode/utf8"
+)
+
+// MaxTagLength is the maximum number of characters in a tag name
+const MaxTagLength = 50
+
+// ParseTags splits a comma-separated list of tags, collapsing whitespace and dropping empty and duplicate tags
+// Duplicates are matched case-insensitively and the first spelling is kept; long tags are cut to MaxTagLength
+// e.g. " Naşi ,colleagues, naşi" becomes ["Naşi", "colleagues"]
+func ParseTags(input string) []string {
+	var tags []string
+	seen := map[string]bool{}
+	for _, part := range strings.Split(input, ",") {
+		tag := strings.Join(strings.Fields(part), " ")
+		if utf8.RuneCountInString(tag) > MaxTagLength {
+			tag = strings.TrimSpace(string([]rune(tag)[:MaxTagLength]))
+		}
+		key := strings.ToLower(tag)
+		if tag == "" || seen[key] {
+			continue
+		}
+		seen[key] = true
+		tags = append(tags, tag)
+	}
+	return tags
+}
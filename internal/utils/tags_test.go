@@ -0,0 +1,55 @@
+package utils
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseTags(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected []string
+	}{
+		{
+			name:     "Empty input",
+			input:    "",
+			expected: nil,
+		},
+		{
+			name:     "Single tag",
+			input:    "colleagues",
+			expected: []string{"colleagues"},
+		},
+		{
+			name:     "Trims and collapses whitespace",
+			input:    "  groom's   family ,  godparents ",
+			expected: []string{"groom's family", "godparents"},
+		},
+		{
+			name:     "Drops empty tags",
+			input:    "family,, ,friends,",
+			expected: []string{"family", "friends"},
+		},
+		{
+			name:     "Drops duplicates case-insensitively keeping the first spelling",
+			input:    "Naşi, colleagues, naşi, COLLEAGUES",
+			expected: []string{"Naşi", "colleagues"},
+		},
+		{
+			name:     "Cuts long tags",
+			input:    strings.Repeat("ă", MaxTagLength+10),
+			expected: []string{strings.Repeat("ă", MaxTagLength)},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := ParseTags(tt.input)
+			if !reflect.DeepEqual(result, tt.expected) {
+				t.Errorf("For input %q, expected %q but got %q", tt.input, tt.expected, result)
+			}
+		})
+	}
+}
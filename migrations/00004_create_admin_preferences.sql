@@ -0,0 +1,13 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE admin_preferences (
+    email TEXT PRIMARY KEY,
+    language TEXT NOT NULL DEFAULT 'ro' CHECK(language IN ('ro', 'en')),
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS admin_preferences;
+-- +goose StatementEnd
@@ -0,0 +1,24 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Every email login request is recorded (token_hash is NULL when the address
+-- is not an admin) so rate limits behave the same for known and unknown emails
+CREATE TABLE admin_login_links (
+    id SERIAL PRIMARY KEY,
+    email TEXT NOT NULL,
+    token_hash TEXT UNIQUE,
+    ip TEXT,
+    expires_at TIMESTAMP NOT NULL,
+    used_at TIMESTAMP NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_admin_login_links_email ON admin_login_links(email, created_at);
+CREATE INDEX idx_admin_login_links_ip ON admin_login_links(ip, created_at);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_admin_login_links_ip;
+DROP INDEX IF EXISTS idx_admin_login_links_email;
+DROP TABLE IF EXISTS admin_login_links;
+-- +goose StatementEnd
@@ -0,0 +1,20 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE abuse_log (
+    id SERIAL PRIMARY KEY,
+    kind TEXT NOT NULL,
+    key TEXT,
+    ip TEXT,
+    path TEXT,
+    user_agent TEXT,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_abuse_log_created_at ON abuse_log(created_at);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_abuse_log_created_at;
+DROP TABLE IF EXISTS abuse_log;
+-- +goose StatementEnd
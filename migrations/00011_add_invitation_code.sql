@@ -0,0 +1,10 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Codes for existing invitations are filled in on startup by EnsureInvitationCodes
+ALTER TABLE invitations ADD COLUMN code TEXT UNIQUE;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE invitations DROP COLUMN code;
+-- +goose StatementEnd
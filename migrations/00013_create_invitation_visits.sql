@@ -0,0 +1,20 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE invitation_visits (
+    id SERIAL PRIMARY KEY,
+    invitation_id INTEGER NOT NULL,
+    language TEXT NOT NULL,
+    device TEXT NOT NULL,
+    form_started BOOLEAN NOT NULL DEFAULT FALSE,
+    visited_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY(invitation_id) REFERENCES invitations(id)
+);
+
+CREATE INDEX idx_invitation_visits_invitation_id ON invitation_visits(invitation_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_invitation_visits_invitation_id;
+DROP TABLE IF EXISTS invitation_visits;
+-- +goose StatementEnd
@@ -0,0 +1,9 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE responses ADD COLUMN withdrawn_at TIMESTAMP;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE responses DROP COLUMN withdrawn_at;
+-- +goose StatementEnd
@@ -0,0 +1,18 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE invitations ADD COLUMN deleted_at TIMESTAMP;
+
+-- Archived invitations keep their phone number, so it only has to be unique among active ones
+ALTER TABLE invitations DROP CONSTRAINT invitations_phone_key;
+CREATE UNIQUE INDEX idx_invitations_phone_active ON invitations(phone) WHERE deleted_at IS NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_invitations_phone_active;
+DELETE FROM responses WHERE invitation_id IN (SELECT id FROM invitations WHERE deleted_at IS NOT NULL);
+DELETE FROM invitation_visits WHERE invitation_id IN (SELECT id FROM invitations WHERE deleted_at IS NOT NULL);
+DELETE FROM invitations WHERE deleted_at IS NOT NULL;
+ALTER TABLE invitations ADD CONSTRAINT invitations_phone_key UNIQUE (phone);
+ALTER TABLE invitations DROP COLUMN deleted_at;
+-- +goose StatementEnd
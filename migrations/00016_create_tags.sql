@@ -0,0 +1,29 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE tags (
+    id SERIAL PRIMARY KEY,
+    name TEXT NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Tags are matched case-insensitively, so "Family" and "family" are the same tag
+CREATE UNIQUE INDEX idx_tags_name ON tags(LOWER(name));
+
+CREATE TABLE invitation_tags (
+    invitation_id INTEGER NOT NULL,
+    tag_id INTEGER NOT NULL,
+    PRIMARY KEY (invitation_id, tag_id),
+    FOREIGN KEY(invitation_id) REFERENCES invitations(id),
+    FOREIGN KEY(tag_id) REFERENCES tags(id)
+);
+
+CREATE INDEX idx_invitation_tags_tag_id ON invitation_tags(tag_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_invitation_tags_tag_id;
+DROP TABLE IF EXISTS invitation_tags;
+DROP INDEX IF EXISTS idx_tags_name;
+DROP TABLE IF EXISTS tags;
+-- +goose StatementEnd
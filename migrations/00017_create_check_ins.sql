@@ -0,0 +1,14 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE check_ins (
+    invitation_id INTEGER PRIMARY KEY,
+    checked_in_by TEXT NOT NULL,
+    checked_in_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY(invitation_id) REFERENCES invitations(id)
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS check_ins;
+-- +goose StatementEnd